@@ -12,15 +12,31 @@ It has these top-level messages:
 	VersionResponse
 	ParseRequest
 	ParseResponse
+	ParseBatchRequest
+	ParseBatchResponse
 	ListDriversRequest
 	ListDriversResponse
+	InstallDriverRequest
+	InstallDriverResponse
+	RemoveDriverRequest
+	RemoveDriverResponse
 	SQLRequest
 	SQLResponse
+	ListQueriesRequest
+	ListQueriesResponse
+	KillQueryRequest
+	KillQueryResponse
 	StartComponentRequest
 	StartComponentResponse
 	StopComponentRequest
 	StopComponentResponse
 	VersionedDriver
+	StatusRequest
+	StatusResponse
+	LogsRequest
+	LogsResponse
+	WatchEventsRequest
+	Event
 */
 package api
 
@@ -116,6 +132,121 @@ func (x ParseResponse_Kind) String() string {
 }
 func (ParseResponse_Kind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
 
+// ConfigStatus compares a driver with the drivers section of the config.
+type ListDriversResponse_ConfigStatus int32
+
+const (
+	// The config does not declare any driver.
+	ListDriversResponse_UNMANAGED ListDriversResponse_ConfigStatus = 0
+	// The installed driver matches the config.
+	ListDriversResponse_OK ListDriversResponse_ConfigStatus = 1
+	// The installed version or image is not the one declared in the
+	// config.
+	ListDriversResponse_OUTDATED ListDriversResponse_ConfigStatus = 2
+	// The driver is declared in the config but it is not installed.
+	ListDriversResponse_MISSING ListDriversResponse_ConfigStatus = 3
+	// The driver is installed but it is not declared in the config.
+	ListDriversResponse_UNDECLARED ListDriversResponse_ConfigStatus = 4
+)
+
+var ListDriversResponse_ConfigStatus_name = map[int32]string{
+	0: "UNMANAGED",
+	1: "OK",
+	2: "OUTDATED",
+	3: "MISSING",
+	4: "UNDECLARED",
+}
+var ListDriversResponse_ConfigStatus_value = map[string]int32{
+	"UNMANAGED":  0,
+	"OK":         1,
+	"OUTDATED":   2,
+	"MISSING":    3,
+	"UNDECLARED": 4,
+}
+
+func (x ListDriversResponse_ConfigStatus) String() string {
+	return proto.EnumName(ListDriversResponse_ConfigStatus_name, int32(x))
+}
+func (ListDriversResponse_ConfigStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 0}
+}
+
+type LogsResponse_Stream int32
+
+const (
+	LogsResponse_STDOUT LogsResponse_Stream = 0
+	LogsResponse_STDERR LogsResponse_Stream = 1
+)
+
+var LogsResponse_Stream_name = map[int32]string{
+	0: "STDOUT",
+	1: "STDERR",
+}
+var LogsResponse_Stream_value = map[string]int32{
+	"STDOUT": 0,
+	"STDERR": 1,
+}
+
+func (x LogsResponse_Stream) String() string {
+	return proto.EnumName(LogsResponse_Stream_name, int32(x))
+}
+func (LogsResponse_Stream) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 0} }
+
+type Event_Type int32
+
+const (
+	Event_UNKNOWN Event_Type = 0
+	// an image pull started
+	Event_PULLING Event_Type = 1
+	// an image pull finished
+	Event_PULLED Event_Type = 2
+	// a container was created
+	Event_CREATED Event_Type = 3
+	// a container was started
+	Event_STARTED Event_Type = 4
+	// a component is answering requests
+	Event_READY Event_Type = 5
+	// a component stopped answering requests
+	Event_NOT_READY Event_Type = 6
+	// a component could not be started
+	Event_FAILED Event_Type = 7
+	// a container was removed
+	Event_REMOVED Event_Type = 8
+	// the progress of an image pull, these events are not kept in the
+	// history
+	Event_PULL_PROGRESS Event_Type = 9
+)
+
+var Event_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PULLING",
+	2: "PULLED",
+	3: "CREATED",
+	4: "STARTED",
+	5: "READY",
+	6: "NOT_READY",
+	7: "FAILED",
+	8: "REMOVED",
+	9: "PULL_PROGRESS",
+}
+var Event_Type_value = map[string]int32{
+	"UNKNOWN":       0,
+	"PULLING":       1,
+	"PULLED":        2,
+	"CREATED":       3,
+	"STARTED":       4,
+	"READY":         5,
+	"NOT_READY":     6,
+	"FAILED":        7,
+	"REMOVED":       8,
+	"PULL_PROGRESS": 9,
+}
+
+func (x Event_Type) String() string {
+	return proto.EnumName(Event_Type_name, int32(x))
+}
+func (Event_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{28, 0} }
+
 type VersionRequest struct {
 }
 
@@ -237,13 +368,82 @@ func (m *ParseResponse) GetLog() string {
 	return ""
 }
 
+type ParseBatchRequest struct {
+	File *ParseRequest `protobuf:"bytes,1,opt,name=file" json:"file,omitempty"`
+	// maximum number of files parsed at the same time, only read from
+	// the first request of the stream. Uses the server default if 0.
+	Parallelism int32 `protobuf:"varint,2,opt,name=parallelism" json:"parallelism,omitempty"`
+}
+
+func (m *ParseBatchRequest) Reset()                    { *m = ParseBatchRequest{} }
+func (m *ParseBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*ParseBatchRequest) ProtoMessage()               {}
+func (*ParseBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ParseBatchRequest) GetFile() *ParseRequest {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *ParseBatchRequest) GetParallelism() int32 {
+	if m != nil {
+		return m.Parallelism
+	}
+	return 0
+}
+
+type ParseBatchResponse struct {
+	// name of the file as sent in the request.
+	Name   string         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Result *ParseResponse `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
+	// set if the file could not be parsed, result is empty then.
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ParseBatchResponse) Reset()                    { *m = ParseBatchResponse{} }
+func (m *ParseBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*ParseBatchResponse) ProtoMessage()               {}
+func (*ParseBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ParseBatchResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ParseBatchResponse) GetResult() *ParseResponse {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *ParseBatchResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type ListDriversRequest struct {
+	// Status requests the state of the driver pools and instances.
+	Status bool `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
 }
 
 func (m *ListDriversRequest) Reset()                    { *m = ListDriversRequest{} }
 func (m *ListDriversRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListDriversRequest) ProtoMessage()               {}
-func (*ListDriversRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*ListDriversRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *ListDriversRequest) GetStatus() bool {
+	if m != nil {
+		return m.Status
+	}
+	return false
+}
 
 type ListDriversResponse struct {
 	Drivers []*ListDriversResponse_DriverInfo `protobuf:"bytes,1,rep,name=drivers" json:"drivers,omitempty"`
@@ -252,7 +452,7 @@ type ListDriversResponse struct {
 func (m *ListDriversResponse) Reset()                    { *m = ListDriversResponse{} }
 func (m *ListDriversResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListDriversResponse) ProtoMessage()               {}
-func (*ListDriversResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*ListDriversResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *ListDriversResponse) GetDrivers() []*ListDriversResponse_DriverInfo {
 	if m != nil {
@@ -261,16 +461,143 @@ func (m *ListDriversResponse) GetDrivers() []*ListDriversResponse_DriverInfo {
 	return nil
 }
 
+type ListDriversResponse_PoolState struct {
+	// Wanted is the number of driver instances wanted.
+	Wanted int32 `protobuf:"varint,1,opt,name=wanted" json:"wanted,omitempty"`
+	// Running is the number of driver instances running.
+	Running int32 `protobuf:"varint,2,opt,name=running" json:"running,omitempty"`
+	// Waiting is the number of requests waiting to be executed.
+	Waiting int32 `protobuf:"varint,3,opt,name=waiting" json:"waiting,omitempty"`
+	// Success is the number of requests executed successfully.
+	Success int32 `protobuf:"varint,4,opt,name=success" json:"success,omitempty"`
+	// Errors is the number of errors processing requests.
+	Errors int32 `protobuf:"varint,5,opt,name=errors" json:"errors,omitempty"`
+	// Exited is the number of driver instances exited unexpectedly.
+	Exited int32 `protobuf:"varint,6,opt,name=exited" json:"exited,omitempty"`
+}
+
+func (m *ListDriversResponse_PoolState) Reset()         { *m = ListDriversResponse_PoolState{} }
+func (m *ListDriversResponse_PoolState) String() string { return proto.CompactTextString(m) }
+func (*ListDriversResponse_PoolState) ProtoMessage()    {}
+func (*ListDriversResponse_PoolState) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 0}
+}
+
+func (m *ListDriversResponse_PoolState) GetWanted() int32 {
+	if m != nil {
+		return m.Wanted
+	}
+	return 0
+}
+
+func (m *ListDriversResponse_PoolState) GetRunning() int32 {
+	if m != nil {
+		return m.Running
+	}
+	return 0
+}
+
+func (m *ListDriversResponse_PoolState) GetWaiting() int32 {
+	if m != nil {
+		return m.Waiting
+	}
+	return 0
+}
+
+func (m *ListDriversResponse_PoolState) GetSuccess() int32 {
+	if m != nil {
+		return m.Success
+	}
+	return 0
+}
+
+func (m *ListDriversResponse_PoolState) GetErrors() int32 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *ListDriversResponse_PoolState) GetExited() int32 {
+	if m != nil {
+		return m.Exited
+	}
+	return 0
+}
+
+type ListDriversResponse_InstanceState struct {
+	Id     string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Image  string `protobuf:"bytes,2,opt,name=image" json:"image,omitempty"`
+	Status string `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	// Created is the unix time in seconds when the instance was created.
+	Created   int64   `protobuf:"varint,4,opt,name=created" json:"created,omitempty"`
+	Processes []int32 `protobuf:"varint,5,rep,packed,name=processes" json:"processes,omitempty"`
+}
+
+func (m *ListDriversResponse_InstanceState) Reset()         { *m = ListDriversResponse_InstanceState{} }
+func (m *ListDriversResponse_InstanceState) String() string { return proto.CompactTextString(m) }
+func (*ListDriversResponse_InstanceState) ProtoMessage()    {}
+func (*ListDriversResponse_InstanceState) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 1}
+}
+
+func (m *ListDriversResponse_InstanceState) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ListDriversResponse_InstanceState) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *ListDriversResponse_InstanceState) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListDriversResponse_InstanceState) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ListDriversResponse_InstanceState) GetProcesses() []int32 {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
 type ListDriversResponse_DriverInfo struct {
-	Lang    string `protobuf:"bytes,1,opt,name=lang" json:"lang,omitempty"`
-	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+	Lang         string                           `protobuf:"bytes,1,opt,name=lang" json:"lang,omitempty"`
+	Version      string                           `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+	ConfigStatus ListDriversResponse_ConfigStatus `protobuf:"varint,3,opt,name=config_status,json=configStatus,enum=ListDriversResponse_ConfigStatus" json:"config_status,omitempty"`
+	// ConfigVersion is the version declared in the config, if any.
+	ConfigVersion string `protobuf:"bytes,4,opt,name=config_version,json=configVersion" json:"config_version,omitempty"`
+	// ConfigImage is the image declared in the config, if any, in the
+	// format name:tag.
+	ConfigImage string `protobuf:"bytes,8,opt,name=config_image,json=configImage" json:"config_image,omitempty"`
+	// Pool is nil if the driver has not been used yet.
+	Pool      *ListDriversResponse_PoolState       `protobuf:"bytes,5,opt,name=pool" json:"pool,omitempty"`
+	Instances []*ListDriversResponse_InstanceState `protobuf:"bytes,6,rep,name=instances" json:"instances,omitempty"`
+	// LastError is the last parsing error for this language seen by the
+	// daemon.
+	LastError string `protobuf:"bytes,7,opt,name=last_error,json=lastError" json:"last_error,omitempty"`
 }
 
 func (m *ListDriversResponse_DriverInfo) Reset()         { *m = ListDriversResponse_DriverInfo{} }
 func (m *ListDriversResponse_DriverInfo) String() string { return proto.CompactTextString(m) }
 func (*ListDriversResponse_DriverInfo) ProtoMessage()    {}
 func (*ListDriversResponse_DriverInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{5, 0}
+	return fileDescriptor0, []int{7, 2}
 }
 
 func (m *ListDriversResponse_DriverInfo) GetLang() string {
@@ -287,14 +614,125 @@ func (m *ListDriversResponse_DriverInfo) GetVersion() string {
 	return ""
 }
 
+func (m *ListDriversResponse_DriverInfo) GetConfigStatus() ListDriversResponse_ConfigStatus {
+	if m != nil {
+		return m.ConfigStatus
+	}
+	return ListDriversResponse_UNMANAGED
+}
+
+func (m *ListDriversResponse_DriverInfo) GetConfigVersion() string {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return ""
+}
+
+func (m *ListDriversResponse_DriverInfo) GetConfigImage() string {
+	if m != nil {
+		return m.ConfigImage
+	}
+	return ""
+}
+
+func (m *ListDriversResponse_DriverInfo) GetPool() *ListDriversResponse_PoolState {
+	if m != nil {
+		return m.Pool
+	}
+	return nil
+}
+
+func (m *ListDriversResponse_DriverInfo) GetInstances() []*ListDriversResponse_InstanceState {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+func (m *ListDriversResponse_DriverInfo) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type InstallDriverRequest struct {
+	Lang string `protobuf:"bytes,1,opt,name=lang" json:"lang,omitempty"`
+	// ImageReference is the docker image of the driver, in the format
+	// name[:tag]. If empty, bblfsh/<lang>-driver:latest will be used.
+	ImageReference string `protobuf:"bytes,2,opt,name=image_reference,json=imageReference" json:"image_reference,omitempty"`
+	// Update replaces the driver if it is already installed.
+	Update bool `protobuf:"varint,3,opt,name=update" json:"update,omitempty"`
+}
+
+func (m *InstallDriverRequest) Reset()                    { *m = InstallDriverRequest{} }
+func (m *InstallDriverRequest) String() string            { return proto.CompactTextString(m) }
+func (*InstallDriverRequest) ProtoMessage()               {}
+func (*InstallDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *InstallDriverRequest) GetLang() string {
+	if m != nil {
+		return m.Lang
+	}
+	return ""
+}
+
+func (m *InstallDriverRequest) GetImageReference() string {
+	if m != nil {
+		return m.ImageReference
+	}
+	return ""
+}
+
+func (m *InstallDriverRequest) GetUpdate() bool {
+	if m != nil {
+		return m.Update
+	}
+	return false
+}
+
+type InstallDriverResponse struct {
+}
+
+func (m *InstallDriverResponse) Reset()                    { *m = InstallDriverResponse{} }
+func (m *InstallDriverResponse) String() string            { return proto.CompactTextString(m) }
+func (*InstallDriverResponse) ProtoMessage()               {}
+func (*InstallDriverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type RemoveDriverRequest struct {
+	Lang string `protobuf:"bytes,1,opt,name=lang" json:"lang,omitempty"`
+}
+
+func (m *RemoveDriverRequest) Reset()                    { *m = RemoveDriverRequest{} }
+func (m *RemoveDriverRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveDriverRequest) ProtoMessage()               {}
+func (*RemoveDriverRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *RemoveDriverRequest) GetLang() string {
+	if m != nil {
+		return m.Lang
+	}
+	return ""
+}
+
+type RemoveDriverResponse struct {
+}
+
+func (m *RemoveDriverResponse) Reset()                    { *m = RemoveDriverResponse{} }
+func (m *RemoveDriverResponse) String() string            { return proto.CompactTextString(m) }
+func (*RemoveDriverResponse) ProtoMessage()               {}
+func (*RemoveDriverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
 type SQLRequest struct {
 	Query string `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
+	// maximum execution time of the query in milliseconds, 0 means no timeout.
+	TimeoutMs int64 `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs" json:"timeout_ms,omitempty"`
 }
 
 func (m *SQLRequest) Reset()                    { *m = SQLRequest{} }
 func (m *SQLRequest) String() string            { return proto.CompactTextString(m) }
 func (*SQLRequest) ProtoMessage()               {}
-func (*SQLRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*SQLRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *SQLRequest) GetQuery() string {
 	if m != nil {
@@ -303,14 +741,25 @@ func (m *SQLRequest) GetQuery() string {
 	return ""
 }
 
+func (m *SQLRequest) GetTimeoutMs() int64 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
 type SQLResponse struct {
+	// For backwards compatibility the first message of the stream contains
+	// the column names as a row too.
 	Row *SQLResponse_Row `protobuf:"bytes,1,opt,name=row" json:"row,omitempty"`
+	// Only set in the first message of the stream.
+	Header *SQLResponse_Header `protobuf:"bytes,2,opt,name=header" json:"header,omitempty"`
 }
 
 func (m *SQLResponse) Reset()                    { *m = SQLResponse{} }
 func (m *SQLResponse) String() string            { return proto.CompactTextString(m) }
 func (*SQLResponse) ProtoMessage()               {}
-func (*SQLResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*SQLResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *SQLResponse) GetRow() *SQLResponse_Row {
 	if m != nil {
@@ -319,14 +768,72 @@ func (m *SQLResponse) GetRow() *SQLResponse_Row {
 	return nil
 }
 
+func (m *SQLResponse) GetHeader() *SQLResponse_Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type SQLResponse_Column struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// database type name as reported by gitbase, e.g. TEXT or INT64.
+	Type     string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Nullable bool   `protobuf:"varint,3,opt,name=nullable" json:"nullable,omitempty"`
+}
+
+func (m *SQLResponse_Column) Reset()                    { *m = SQLResponse_Column{} }
+func (m *SQLResponse_Column) String() string            { return proto.CompactTextString(m) }
+func (*SQLResponse_Column) ProtoMessage()               {}
+func (*SQLResponse_Column) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13, 0} }
+
+func (m *SQLResponse_Column) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SQLResponse_Column) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SQLResponse_Column) GetNullable() bool {
+	if m != nil {
+		return m.Nullable
+	}
+	return false
+}
+
+type SQLResponse_Header struct {
+	Columns []*SQLResponse_Column `protobuf:"bytes,1,rep,name=columns" json:"columns,omitempty"`
+}
+
+func (m *SQLResponse_Header) Reset()                    { *m = SQLResponse_Header{} }
+func (m *SQLResponse_Header) String() string            { return proto.CompactTextString(m) }
+func (*SQLResponse_Header) ProtoMessage()               {}
+func (*SQLResponse_Header) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13, 1} }
+
+func (m *SQLResponse_Header) GetColumns() []*SQLResponse_Column {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
 type SQLResponse_Row struct {
 	Cell [][]byte `protobuf:"bytes,1,rep,name=cell,proto3" json:"cell,omitempty"`
+	// null[i] is true if cell[i] is NULL, cell[i] is empty then.
+	Null []bool `protobuf:"varint,2,rep,packed,name=null" json:"null,omitempty"`
 }
 
 func (m *SQLResponse_Row) Reset()                    { *m = SQLResponse_Row{} }
 func (m *SQLResponse_Row) String() string            { return proto.CompactTextString(m) }
 func (*SQLResponse_Row) ProtoMessage()               {}
-func (*SQLResponse_Row) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 0} }
+func (*SQLResponse_Row) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13, 2} }
 
 func (m *SQLResponse_Row) GetCell() [][]byte {
 	if m != nil {
@@ -335,6 +842,128 @@ func (m *SQLResponse_Row) GetCell() [][]byte {
 	return nil
 }
 
+func (m *SQLResponse_Row) GetNull() []bool {
+	if m != nil {
+		return m.Null
+	}
+	return nil
+}
+
+type ListQueriesRequest struct {
+}
+
+func (m *ListQueriesRequest) Reset()                    { *m = ListQueriesRequest{} }
+func (m *ListQueriesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListQueriesRequest) ProtoMessage()               {}
+func (*ListQueriesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+type ListQueriesResponse struct {
+	Queries []*ListQueriesResponse_Query `protobuf:"bytes,1,rep,name=queries" json:"queries,omitempty"`
+}
+
+func (m *ListQueriesResponse) Reset()                    { *m = ListQueriesResponse{} }
+func (m *ListQueriesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListQueriesResponse) ProtoMessage()               {}
+func (*ListQueriesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *ListQueriesResponse) GetQueries() []*ListQueriesResponse_Query {
+	if m != nil {
+		return m.Queries
+	}
+	return nil
+}
+
+type ListQueriesResponse_Query struct {
+	// id of the gitbase connection running the query, used to kill it.
+	Id      int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	User    string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	Host    string `protobuf:"bytes,3,opt,name=host" json:"host,omitempty"`
+	Command string `protobuf:"bytes,4,opt,name=command" json:"command,omitempty"`
+	// seconds since the query started.
+	Time int64 `protobuf:"varint,5,opt,name=time" json:"time,omitempty"`
+	// progress of the query as reported by gitbase.
+	State string `protobuf:"bytes,6,opt,name=state" json:"state,omitempty"`
+	Query string `protobuf:"bytes,7,opt,name=query" json:"query,omitempty"`
+}
+
+func (m *ListQueriesResponse_Query) Reset()                    { *m = ListQueriesResponse_Query{} }
+func (m *ListQueriesResponse_Query) String() string            { return proto.CompactTextString(m) }
+func (*ListQueriesResponse_Query) ProtoMessage()               {}
+func (*ListQueriesResponse_Query) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15, 0} }
+
+func (m *ListQueriesResponse_Query) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ListQueriesResponse_Query) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ListQueriesResponse_Query) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *ListQueriesResponse_Query) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *ListQueriesResponse_Query) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ListQueriesResponse_Query) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ListQueriesResponse_Query) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+type KillQueryRequest struct {
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *KillQueryRequest) Reset()                    { *m = KillQueryRequest{} }
+func (m *KillQueryRequest) String() string            { return proto.CompactTextString(m) }
+func (*KillQueryRequest) ProtoMessage()               {}
+func (*KillQueryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *KillQueryRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type KillQueryResponse struct {
+}
+
+func (m *KillQueryResponse) Reset()                    { *m = KillQueryResponse{} }
+func (m *KillQueryResponse) String() string            { return proto.CompactTextString(m) }
+func (*KillQueryResponse) ProtoMessage()               {}
+func (*KillQueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
 type StartComponentRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	// Port is the public port binding.
@@ -346,7 +975,7 @@ type StartComponentRequest struct {
 func (m *StartComponentRequest) Reset()                    { *m = StartComponentRequest{} }
 func (m *StartComponentRequest) String() string            { return proto.CompactTextString(m) }
 func (*StartComponentRequest) ProtoMessage()               {}
-func (*StartComponentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*StartComponentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *StartComponentRequest) GetName() string {
 	if m != nil {
@@ -366,12 +995,14 @@ type StartComponentResponse struct {
 	// Port is the public port binding for the container.
 	// It may be 0 if the container does not have a port binding.
 	Port int32 `protobuf:"varint,1,opt,name=port" json:"port,omitempty"`
+	// HostIp is the host address the public port is bound to.
+	HostIp string `protobuf:"bytes,2,opt,name=host_ip,json=hostIp" json:"host_ip,omitempty"`
 }
 
 func (m *StartComponentResponse) Reset()                    { *m = StartComponentResponse{} }
 func (m *StartComponentResponse) String() string            { return proto.CompactTextString(m) }
 func (*StartComponentResponse) ProtoMessage()               {}
-func (*StartComponentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*StartComponentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *StartComponentResponse) GetPort() int32 {
 	if m != nil {
@@ -380,6 +1011,13 @@ func (m *StartComponentResponse) GetPort() int32 {
 	return 0
 }
 
+func (m *StartComponentResponse) GetHostIp() string {
+	if m != nil {
+		return m.HostIp
+	}
+	return ""
+}
+
 type StopComponentRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 }
@@ -387,66 +1025,495 @@ type StopComponentRequest struct {
 func (m *StopComponentRequest) Reset()                    { *m = StopComponentRequest{} }
 func (m *StopComponentRequest) String() string            { return proto.CompactTextString(m) }
 func (*StopComponentRequest) ProtoMessage()               {}
-func (*StopComponentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*StopComponentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *StopComponentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type StopComponentResponse struct {
+}
+
+func (m *StopComponentResponse) Reset()                    { *m = StopComponentResponse{} }
+func (m *StopComponentResponse) String() string            { return proto.CompactTextString(m) }
+func (*StopComponentResponse) ProtoMessage()               {}
+func (*StopComponentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+type VersionedDriver struct {
+	Language string `protobuf:"bytes,1,opt,name=language" json:"language,omitempty"`
+	Version  string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *VersionedDriver) Reset()                    { *m = VersionedDriver{} }
+func (m *VersionedDriver) String() string            { return proto.CompactTextString(m) }
+func (*VersionedDriver) ProtoMessage()               {}
+func (*VersionedDriver) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *VersionedDriver) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *VersionedDriver) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+type StatusRequest struct {
+}
+
+func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
+func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()               {}
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+type StatusResponse struct {
+	Version string `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	Workdir string `protobuf:"bytes,2,opt,name=workdir" json:"workdir,omitempty"`
+	HostOs  string `protobuf:"bytes,3,opt,name=host_os,json=hostOs" json:"host_os,omitempty"`
+	// config is the effective config of the daemon, in YAML
+	Config     string                      `protobuf:"bytes,4,opt,name=config" json:"config,omitempty"`
+	Components []*StatusResponse_Component `protobuf:"bytes,5,rep,name=components" json:"components,omitempty"`
+	// workspace is the name of the workspace served by the daemon
+	Workspace string `protobuf:"bytes,6,opt,name=workspace" json:"workspace,omitempty"`
+}
+
+func (m *StatusResponse) Reset()                    { *m = StatusResponse{} }
+func (m *StatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()               {}
+func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *StatusResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetWorkdir() string {
+	if m != nil {
+		return m.Workdir
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetHostOs() string {
+	if m != nil {
+		return m.HostOs
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetConfig() string {
+	if m != nil {
+		return m.Config
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetComponents() []*StatusResponse_Component {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
+type StatusResponse_Port struct {
+	HostIp      string `protobuf:"bytes,1,opt,name=host_ip,json=hostIp" json:"host_ip,omitempty"`
+	PublicPort  int32  `protobuf:"varint,2,opt,name=public_port,json=publicPort" json:"public_port,omitempty"`
+	PrivatePort int32  `protobuf:"varint,3,opt,name=private_port,json=privatePort" json:"private_port,omitempty"`
+}
+
+func (m *StatusResponse_Port) Reset()                    { *m = StatusResponse_Port{} }
+func (m *StatusResponse_Port) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse_Port) ProtoMessage()               {}
+func (*StatusResponse_Port) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24, 0} }
+
+func (m *StatusResponse_Port) GetHostIp() string {
+	if m != nil {
+		return m.HostIp
+	}
+	return ""
+}
+
+func (m *StatusResponse_Port) GetPublicPort() int32 {
+	if m != nil {
+		return m.PublicPort
+	}
+	return 0
+}
+
+func (m *StatusResponse_Port) GetPrivatePort() int32 {
+	if m != nil {
+		return m.PrivatePort
+	}
+	return 0
+}
+
+// Limits are the resource limits of a container, 0 means no limit.
+type StatusResponse_Limits struct {
+	Cpus float64 `protobuf:"fixed64,1,opt,name=cpus" json:"cpus,omitempty"`
+	// memory and memory_swap are in bytes, memory_swap is -1 for
+	// unlimited swap
+	Memory     int64 `protobuf:"varint,2,opt,name=memory" json:"memory,omitempty"`
+	MemorySwap int64 `protobuf:"varint,3,opt,name=memory_swap,json=memorySwap" json:"memory_swap,omitempty"`
+	PidsLimit  int64 `protobuf:"varint,4,opt,name=pids_limit,json=pidsLimit" json:"pids_limit,omitempty"`
+}
+
+func (m *StatusResponse_Limits) Reset()                    { *m = StatusResponse_Limits{} }
+func (m *StatusResponse_Limits) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse_Limits) ProtoMessage()               {}
+func (*StatusResponse_Limits) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24, 1} }
+
+func (m *StatusResponse_Limits) GetCpus() float64 {
+	if m != nil {
+		return m.Cpus
+	}
+	return 0
+}
+
+func (m *StatusResponse_Limits) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *StatusResponse_Limits) GetMemorySwap() int64 {
+	if m != nil {
+		return m.MemorySwap
+	}
+	return 0
+}
+
+func (m *StatusResponse_Limits) GetPidsLimit() int64 {
+	if m != nil {
+		return m.PidsLimit
+	}
+	return 0
+}
+
+type StatusResponse_Component struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Image string `protobuf:"bytes,2,opt,name=image" json:"image,omitempty"`
+	// state of the container as reported by docker, or "not created"
+	State string `protobuf:"bytes,3,opt,name=state" json:"state,omitempty"`
+	// ready is true if the component is running and answering requests
+	Ready  bool                   `protobuf:"varint,4,opt,name=ready" json:"ready,omitempty"`
+	Ports  []*StatusResponse_Port `protobuf:"bytes,5,rep,name=ports" json:"ports,omitempty"`
+	Limits *StatusResponse_Limits `protobuf:"bytes,6,opt,name=limits" json:"limits,omitempty"`
+}
+
+func (m *StatusResponse_Component) Reset()                    { *m = StatusResponse_Component{} }
+func (m *StatusResponse_Component) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse_Component) ProtoMessage()               {}
+func (*StatusResponse_Component) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24, 2} }
+
+func (m *StatusResponse_Component) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StatusResponse_Component) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *StatusResponse_Component) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *StatusResponse_Component) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *StatusResponse_Component) GetPorts() []*StatusResponse_Port {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *StatusResponse_Component) GetLimits() *StatusResponse_Limits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+type LogsRequest struct {
+	// component is the name of the component: gitbase, bblfshd, gitbase-web,
+	// bblfsh-web or daemon. Its container name or image are accepted too
+	Component string `protobuf:"bytes,1,opt,name=component" json:"component,omitempty"`
+	// follow keeps streaming the new logs
+	Follow bool `protobuf:"varint,2,opt,name=follow" json:"follow,omitempty"`
+	// since is a timestamp (RFC3339) or a relative duration (like 10m) of the
+	// first logs to show
+	Since string `protobuf:"bytes,3,opt,name=since" json:"since,omitempty"`
+	// tail is the number of lines to show from the end of the logs, a
+	// negative value shows all of them
+	Tail int32 `protobuf:"varint,4,opt,name=tail" json:"tail,omitempty"`
+}
+
+func (m *LogsRequest) Reset()                    { *m = LogsRequest{} }
+func (m *LogsRequest) String() string            { return proto.CompactTextString(m) }
+func (*LogsRequest) ProtoMessage()               {}
+func (*LogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *LogsRequest) GetComponent() string {
+	if m != nil {
+		return m.Component
+	}
+	return ""
+}
+
+func (m *LogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *LogsRequest) GetSince() string {
+	if m != nil {
+		return m.Since
+	}
+	return ""
+}
+
+func (m *LogsRequest) GetTail() int32 {
+	if m != nil {
+		return m.Tail
+	}
+	return 0
+}
+
+type LogsResponse struct {
+	Stream LogsResponse_Stream `protobuf:"varint,1,opt,name=stream,enum=LogsResponse_Stream" json:"stream,omitempty"`
+	Data   []byte              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *LogsResponse) Reset()                    { *m = LogsResponse{} }
+func (m *LogsResponse) String() string            { return proto.CompactTextString(m) }
+func (*LogsResponse) ProtoMessage()               {}
+func (*LogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *LogsResponse) GetStream() LogsResponse_Stream {
+	if m != nil {
+		return m.Stream
+	}
+	return LogsResponse_STDOUT
+}
+
+func (m *LogsResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	// history sends the events kept in memory before the new ones
+	History bool `protobuf:"varint,1,opt,name=history" json:"history,omitempty"`
+}
+
+func (m *WatchEventsRequest) Reset()                    { *m = WatchEventsRequest{} }
+func (m *WatchEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchEventsRequest) ProtoMessage()               {}
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *WatchEventsRequest) GetHistory() bool {
+	if m != nil {
+		return m.History
+	}
+	return false
+}
+
+type Event struct {
+	// id increases with each event of the daemon
+	Id   uint64     `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Type Event_Type `protobuf:"varint,2,opt,name=type,enum=Event_Type" json:"type,omitempty"`
+	// time of the event in Unix nanoseconds
+	Time int64 `protobuf:"varint,3,opt,name=time" json:"time,omitempty"`
+	// component is the container name, empty for image events
+	Component string `protobuf:"bytes,4,opt,name=component" json:"component,omitempty"`
+	Image     string `protobuf:"bytes,5,opt,name=image" json:"image,omitempty"`
+	// error is the cause of a FAILED event
+	Error string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	// layers is the progress of the image layers of a PULL_PROGRESS event
+	Layers []*Event_Layer `protobuf:"bytes,7,rep,name=layers" json:"layers,omitempty"`
+}
+
+func (m *Event) Reset()                    { *m = Event{} }
+func (m *Event) String() string            { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()               {}
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *Event) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Event) GetType() Event_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Event_UNKNOWN
+}
+
+func (m *Event) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Event) GetComponent() string {
+	if m != nil {
+		return m.Component
+	}
+	return ""
+}
+
+func (m *Event) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
 
-func (m *StopComponentRequest) GetName() string {
+func (m *Event) GetError() string {
 	if m != nil {
-		return m.Name
+		return m.Error
 	}
 	return ""
 }
 
-type StopComponentResponse struct {
+func (m *Event) GetLayers() []*Event_Layer {
+	if m != nil {
+		return m.Layers
+	}
+	return nil
 }
 
-func (m *StopComponentResponse) Reset()                    { *m = StopComponentResponse{} }
-func (m *StopComponentResponse) String() string            { return proto.CompactTextString(m) }
-func (*StopComponentResponse) ProtoMessage()               {}
-func (*StopComponentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
-
-type VersionedDriver struct {
-	Language string `protobuf:"bytes,1,opt,name=language" json:"language,omitempty"`
-	Version  string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+// Layer is the pull progress of an image layer
+type Event_Layer struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// status is the last status reported by docker, like Downloading
+	// or Extracting
+	Status string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	// current is the number of bytes processed by the current status
+	Current int64 `protobuf:"varint,3,opt,name=current" json:"current,omitempty"`
+	// total is the size of the layer, 0 while it is unknown
+	Total int64 `protobuf:"varint,4,opt,name=total" json:"total,omitempty"`
 }
 
-func (m *VersionedDriver) Reset()                    { *m = VersionedDriver{} }
-func (m *VersionedDriver) String() string            { return proto.CompactTextString(m) }
-func (*VersionedDriver) ProtoMessage()               {}
-func (*VersionedDriver) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *Event_Layer) Reset()                    { *m = Event_Layer{} }
+func (m *Event_Layer) String() string            { return proto.CompactTextString(m) }
+func (*Event_Layer) ProtoMessage()               {}
+func (*Event_Layer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28, 0} }
 
-func (m *VersionedDriver) GetLanguage() string {
+func (m *Event_Layer) GetId() string {
 	if m != nil {
-		return m.Language
+		return m.Id
 	}
 	return ""
 }
 
-func (m *VersionedDriver) GetVersion() string {
+func (m *Event_Layer) GetStatus() string {
 	if m != nil {
-		return m.Version
+		return m.Status
 	}
 	return ""
 }
 
+func (m *Event_Layer) GetCurrent() int64 {
+	if m != nil {
+		return m.Current
+	}
+	return 0
+}
+
+func (m *Event_Layer) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*VersionRequest)(nil), "VersionRequest")
 	proto.RegisterType((*VersionResponse)(nil), "VersionResponse")
 	proto.RegisterType((*ParseRequest)(nil), "ParseRequest")
 	proto.RegisterType((*ParseResponse)(nil), "ParseResponse")
+	proto.RegisterType((*ParseBatchRequest)(nil), "ParseBatchRequest")
+	proto.RegisterType((*ParseBatchResponse)(nil), "ParseBatchResponse")
 	proto.RegisterType((*ListDriversRequest)(nil), "ListDriversRequest")
 	proto.RegisterType((*ListDriversResponse)(nil), "ListDriversResponse")
+	proto.RegisterType((*ListDriversResponse_PoolState)(nil), "ListDriversResponse.PoolState")
+	proto.RegisterType((*ListDriversResponse_InstanceState)(nil), "ListDriversResponse.InstanceState")
 	proto.RegisterType((*ListDriversResponse_DriverInfo)(nil), "ListDriversResponse.DriverInfo")
+	proto.RegisterType((*InstallDriverRequest)(nil), "InstallDriverRequest")
+	proto.RegisterType((*InstallDriverResponse)(nil), "InstallDriverResponse")
+	proto.RegisterType((*RemoveDriverRequest)(nil), "RemoveDriverRequest")
+	proto.RegisterType((*RemoveDriverResponse)(nil), "RemoveDriverResponse")
 	proto.RegisterType((*SQLRequest)(nil), "SQLRequest")
 	proto.RegisterType((*SQLResponse)(nil), "SQLResponse")
+	proto.RegisterType((*SQLResponse_Column)(nil), "SQLResponse.Column")
+	proto.RegisterType((*SQLResponse_Header)(nil), "SQLResponse.Header")
 	proto.RegisterType((*SQLResponse_Row)(nil), "SQLResponse.Row")
+	proto.RegisterType((*ListQueriesRequest)(nil), "ListQueriesRequest")
+	proto.RegisterType((*ListQueriesResponse)(nil), "ListQueriesResponse")
+	proto.RegisterType((*ListQueriesResponse_Query)(nil), "ListQueriesResponse.Query")
+	proto.RegisterType((*KillQueryRequest)(nil), "KillQueryRequest")
+	proto.RegisterType((*KillQueryResponse)(nil), "KillQueryResponse")
 	proto.RegisterType((*StartComponentRequest)(nil), "StartComponentRequest")
 	proto.RegisterType((*StartComponentResponse)(nil), "StartComponentResponse")
 	proto.RegisterType((*StopComponentRequest)(nil), "StopComponentRequest")
 	proto.RegisterType((*StopComponentResponse)(nil), "StopComponentResponse")
 	proto.RegisterType((*VersionedDriver)(nil), "VersionedDriver")
+	proto.RegisterType((*StatusRequest)(nil), "StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "StatusResponse")
+	proto.RegisterType((*StatusResponse_Port)(nil), "StatusResponse.Port")
+	proto.RegisterType((*StatusResponse_Limits)(nil), "StatusResponse.Limits")
+	proto.RegisterType((*StatusResponse_Component)(nil), "StatusResponse.Component")
+	proto.RegisterType((*LogsRequest)(nil), "LogsRequest")
+	proto.RegisterType((*LogsResponse)(nil), "LogsResponse")
+	proto.RegisterType((*WatchEventsRequest)(nil), "WatchEventsRequest")
+	proto.RegisterType((*Event)(nil), "Event")
+	proto.RegisterType((*Event_Layer)(nil), "Event.Layer")
 	proto.RegisterEnum("ParseRequest_Kind", ParseRequest_Kind_name, ParseRequest_Kind_value)
 	proto.RegisterEnum("ParseRequest_UastMode", ParseRequest_UastMode_name, ParseRequest_UastMode_value)
 	proto.RegisterEnum("ParseResponse_Kind", ParseResponse_Kind_name, ParseResponse_Kind_value)
+	proto.RegisterEnum("ListDriversResponse_ConfigStatus", ListDriversResponse_ConfigStatus_name, ListDriversResponse_ConfigStatus_value)
+	proto.RegisterEnum("LogsResponse_Stream", LogsResponse_Stream_name, LogsResponse_Stream_value)
+	proto.RegisterEnum("Event_Type", Event_Type_name, Event_Type_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -465,15 +1532,31 @@ type EngineClient interface {
 	Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error)
 	// A stream of responses with logs and finally the parsing result.
 	ParseWithLogs(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (Engine_ParseWithLogsClient, error)
+	// Parses a stream of files concurrently and streams back a result per file.
+	ParseBatch(ctx context.Context, opts ...grpc.CallOption) (Engine_ParseBatchClient, error)
 	// Driver management.
 	// List all drivers.
 	ListDrivers(ctx context.Context, in *ListDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
+	// Install a driver for the given language.
+	InstallDriver(ctx context.Context, in *InstallDriverRequest, opts ...grpc.CallOption) (*InstallDriverResponse, error)
+	// Remove the driver for the given language.
+	RemoveDriver(ctx context.Context, in *RemoveDriverRequest, opts ...grpc.CallOption) (*RemoveDriverResponse, error)
 	// SQL stuff.
 	SQL(ctx context.Context, in *SQLRequest, opts ...grpc.CallOption) (Engine_SQLClient, error)
+	// List the queries running in gitbase.
+	ListQueries(ctx context.Context, in *ListQueriesRequest, opts ...grpc.CallOption) (*ListQueriesResponse, error)
+	// Kill a query running in gitbase.
+	KillQuery(ctx context.Context, in *KillQueryRequest, opts ...grpc.CallOption) (*KillQueryResponse, error)
 	// Start a component.
 	StartComponent(ctx context.Context, in *StartComponentRequest, opts ...grpc.CallOption) (*StartComponentResponse, error)
 	// Stop a component.
 	StopComponent(ctx context.Context, in *StopComponentRequest, opts ...grpc.CallOption) (*StopComponentResponse, error)
+	// State of the daemon and its components.
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Stream the logs of a component container.
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Engine_LogsClient, error)
+	// Stream the lifecycle events of the components.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Engine_WatchEventsClient, error)
 }
 
 type engineClient struct {
@@ -534,6 +1617,37 @@ func (x *engineParseWithLogsClient) Recv() (*ParseResponse, error) {
 	return m, nil
 }
 
+func (c *engineClient) ParseBatch(ctx context.Context, opts ...grpc.CallOption) (Engine_ParseBatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Engine_serviceDesc.Streams[1], c.cc, "/Engine/ParseBatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &engineParseBatchClient{stream}
+	return x, nil
+}
+
+type Engine_ParseBatchClient interface {
+	Send(*ParseBatchRequest) error
+	Recv() (*ParseBatchResponse, error)
+	grpc.ClientStream
+}
+
+type engineParseBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *engineParseBatchClient) Send(m *ParseBatchRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *engineParseBatchClient) Recv() (*ParseBatchResponse, error) {
+	m := new(ParseBatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *engineClient) ListDrivers(ctx context.Context, in *ListDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
 	out := new(ListDriversResponse)
 	err := grpc.Invoke(ctx, "/Engine/ListDrivers", in, out, c.cc, opts...)
@@ -543,8 +1657,26 @@ func (c *engineClient) ListDrivers(ctx context.Context, in *ListDriversRequest,
 	return out, nil
 }
 
+func (c *engineClient) InstallDriver(ctx context.Context, in *InstallDriverRequest, opts ...grpc.CallOption) (*InstallDriverResponse, error) {
+	out := new(InstallDriverResponse)
+	err := grpc.Invoke(ctx, "/Engine/InstallDriver", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) RemoveDriver(ctx context.Context, in *RemoveDriverRequest, opts ...grpc.CallOption) (*RemoveDriverResponse, error) {
+	out := new(RemoveDriverResponse)
+	err := grpc.Invoke(ctx, "/Engine/RemoveDriver", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *engineClient) SQL(ctx context.Context, in *SQLRequest, opts ...grpc.CallOption) (Engine_SQLClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Engine_serviceDesc.Streams[1], c.cc, "/Engine/SQL", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Engine_serviceDesc.Streams[2], c.cc, "/Engine/SQL", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -575,6 +1707,24 @@ func (x *engineSQLClient) Recv() (*SQLResponse, error) {
 	return m, nil
 }
 
+func (c *engineClient) ListQueries(ctx context.Context, in *ListQueriesRequest, opts ...grpc.CallOption) (*ListQueriesResponse, error) {
+	out := new(ListQueriesResponse)
+	err := grpc.Invoke(ctx, "/Engine/ListQueries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) KillQuery(ctx context.Context, in *KillQueryRequest, opts ...grpc.CallOption) (*KillQueryResponse, error) {
+	out := new(KillQueryResponse)
+	err := grpc.Invoke(ctx, "/Engine/KillQuery", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *engineClient) StartComponent(ctx context.Context, in *StartComponentRequest, opts ...grpc.CallOption) (*StartComponentResponse, error) {
 	out := new(StartComponentResponse)
 	err := grpc.Invoke(ctx, "/Engine/StartComponent", in, out, c.cc, opts...)
@@ -593,6 +1743,79 @@ func (c *engineClient) StopComponent(ctx context.Context, in *StopComponentReque
 	return out, nil
 }
 
+func (c *engineClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := grpc.Invoke(ctx, "/Engine/Status", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (Engine_LogsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Engine_serviceDesc.Streams[3], c.cc, "/Engine/Logs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &engineLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Engine_LogsClient interface {
+	Recv() (*LogsResponse, error)
+	grpc.ClientStream
+}
+
+type engineLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *engineLogsClient) Recv() (*LogsResponse, error) {
+	m := new(LogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *engineClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Engine_WatchEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Engine_serviceDesc.Streams[4], c.cc, "/Engine/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &engineWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Engine_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type engineWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *engineWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Engine service
 
 type EngineServer interface {
@@ -601,15 +1824,31 @@ type EngineServer interface {
 	Parse(context.Context, *ParseRequest) (*ParseResponse, error)
 	// A stream of responses with logs and finally the parsing result.
 	ParseWithLogs(*ParseRequest, Engine_ParseWithLogsServer) error
+	// Parses a stream of files concurrently and streams back a result per file.
+	ParseBatch(Engine_ParseBatchServer) error
 	// Driver management.
 	// List all drivers.
 	ListDrivers(context.Context, *ListDriversRequest) (*ListDriversResponse, error)
+	// Install a driver for the given language.
+	InstallDriver(context.Context, *InstallDriverRequest) (*InstallDriverResponse, error)
+	// Remove the driver for the given language.
+	RemoveDriver(context.Context, *RemoveDriverRequest) (*RemoveDriverResponse, error)
 	// SQL stuff.
 	SQL(*SQLRequest, Engine_SQLServer) error
+	// List the queries running in gitbase.
+	ListQueries(context.Context, *ListQueriesRequest) (*ListQueriesResponse, error)
+	// Kill a query running in gitbase.
+	KillQuery(context.Context, *KillQueryRequest) (*KillQueryResponse, error)
 	// Start a component.
 	StartComponent(context.Context, *StartComponentRequest) (*StartComponentResponse, error)
 	// Stop a component.
 	StopComponent(context.Context, *StopComponentRequest) (*StopComponentResponse, error)
+	// State of the daemon and its components.
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// Stream the logs of a component container.
+	Logs(*LogsRequest, Engine_LogsServer) error
+	// Stream the lifecycle events of the components.
+	WatchEvents(*WatchEventsRequest, Engine_WatchEventsServer) error
 }
 
 func RegisterEngineServer(s *grpc.Server, srv EngineServer) {
@@ -673,6 +1912,32 @@ func (x *engineParseWithLogsServer) Send(m *ParseResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Engine_ParseBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EngineServer).ParseBatch(&engineParseBatchServer{stream})
+}
+
+type Engine_ParseBatchServer interface {
+	Send(*ParseBatchResponse) error
+	Recv() (*ParseBatchRequest, error)
+	grpc.ServerStream
+}
+
+type engineParseBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *engineParseBatchServer) Send(m *ParseBatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *engineParseBatchServer) Recv() (*ParseBatchRequest, error) {
+	m := new(ParseBatchRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Engine_ListDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListDriversRequest)
 	if err := dec(in); err != nil {
@@ -691,6 +1956,42 @@ func _Engine_ListDrivers_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Engine_InstallDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).InstallDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Engine/InstallDriver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).InstallDriver(ctx, req.(*InstallDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_RemoveDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).RemoveDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Engine/RemoveDriver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).RemoveDriver(ctx, req.(*RemoveDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Engine_SQL_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SQLRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -712,6 +2013,42 @@ func (x *engineSQLServer) Send(m *SQLResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Engine_ListQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).ListQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Engine/ListQueries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).ListQueries(ctx, req.(*ListQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_KillQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).KillQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Engine/KillQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).KillQuery(ctx, req.(*KillQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Engine_StartComponent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartComponentRequest)
 	if err := dec(in); err != nil {
@@ -748,6 +2085,66 @@ func _Engine_StopComponent_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Engine_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Engine/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Engine_Logs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EngineServer).Logs(m, &engineLogsServer{stream})
+}
+
+type Engine_LogsServer interface {
+	Send(*LogsResponse) error
+	grpc.ServerStream
+}
+
+type engineLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *engineLogsServer) Send(m *LogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Engine_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EngineServer).WatchEvents(m, &engineWatchEventsServer{stream})
+}
+
+type Engine_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type engineWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *engineWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Engine_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "Engine",
 	HandlerType: (*EngineServer)(nil),
@@ -764,6 +2161,22 @@ var _Engine_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListDrivers",
 			Handler:    _Engine_ListDrivers_Handler,
 		},
+		{
+			MethodName: "InstallDriver",
+			Handler:    _Engine_InstallDriver_Handler,
+		},
+		{
+			MethodName: "RemoveDriver",
+			Handler:    _Engine_RemoveDriver_Handler,
+		},
+		{
+			MethodName: "ListQueries",
+			Handler:    _Engine_ListQueries_Handler,
+		},
+		{
+			MethodName: "KillQuery",
+			Handler:    _Engine_KillQuery_Handler,
+		},
 		{
 			MethodName: "StartComponent",
 			Handler:    _Engine_StartComponent_Handler,
@@ -772,6 +2185,10 @@ var _Engine_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StopComponent",
 			Handler:    _Engine_StopComponent_Handler,
 		},
+		{
+			MethodName: "Status",
+			Handler:    _Engine_Status_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -779,11 +2196,27 @@ var _Engine_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Engine_ParseWithLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ParseBatch",
+			Handler:       _Engine_ParseBatch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "SQL",
 			Handler:       _Engine_SQL_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Logs",
+			Handler:       _Engine_Logs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Engine_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
@@ -791,46 +2224,133 @@ var _Engine_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 656 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdb, 0x6e, 0xd3, 0x4c,
-	0x10, 0xf6, 0x29, 0xa7, 0xc9, 0xa1, 0xd6, 0x34, 0x4d, 0xfd, 0xfb, 0xe6, 0xaf, 0x56, 0x88, 0x46,
-	0x05, 0xad, 0x50, 0x7a, 0x45, 0x6f, 0xc0, 0x6a, 0x43, 0x15, 0xe1, 0xba, 0xd4, 0x49, 0xcb, 0xb5,
-	0x69, 0x96, 0x60, 0x91, 0x7a, 0x53, 0xdb, 0xa1, 0xe2, 0x1d, 0x78, 0x00, 0x24, 0x1e, 0x16, 0xe4,
-	0xb5, 0x9d, 0xda, 0xc1, 0x08, 0xee, 0x66, 0x67, 0xbe, 0x9d, 0xd3, 0xf7, 0xed, 0x42, 0xcb, 0x5b,
-	0xf9, 0x74, 0x15, 0xf2, 0x98, 0x13, 0x1d, 0x7a, 0x37, 0x2c, 0x8c, 0x7c, 0x1e, 0xb8, 0xec, 0x7e,
-	0xcd, 0xa2, 0x98, 0x3c, 0x83, 0x9d, 0x8d, 0x27, 0x5a, 0xf1, 0x20, 0x62, 0x68, 0x40, 0xe3, 0x4b,
-	0xea, 0x32, 0xe4, 0x03, 0x79, 0xd8, 0x72, 0xf3, 0x23, 0xf9, 0xae, 0x40, 0xe7, 0x9d, 0x17, 0x46,
-	0x2c, 0xbb, 0x8d, 0x4f, 0x41, 0xfb, 0xec, 0x07, 0x73, 0x81, 0xeb, 0x8d, 0x90, 0x16, 0x83, 0xf4,
-	0xad, 0x1f, 0xcc, 0x5d, 0x11, 0x47, 0x04, 0x2d, 0xf0, 0xee, 0x98, 0xa1, 0x88, 0x7c, 0xc2, 0x4e,
-	0xca, 0xdc, 0xf2, 0x20, 0x66, 0x41, 0x6c, 0xa8, 0x07, 0xf2, 0xb0, 0xe3, 0xe6, 0xc7, 0x04, 0xbd,
-	0xf4, 0x82, 0x85, 0xa1, 0xa5, 0xe8, 0xc4, 0xc6, 0x3e, 0xd4, 0xee, 0xd7, 0x2c, 0xfc, 0x6a, 0xd4,
-	0x84, 0x33, 0x3d, 0xe0, 0x11, 0x68, 0x77, 0x7c, 0xce, 0x8c, 0xba, 0xa8, 0x3f, 0x28, 0xd7, 0xbf,
-	0xf6, 0xa2, 0xf8, 0x82, 0xcf, 0x99, 0x2b, 0x30, 0xe4, 0x10, 0xb4, 0xa4, 0x23, 0x6c, 0x43, 0x63,
-	0xe2, 0xdc, 0x58, 0xf6, 0xe4, 0x4c, 0x97, 0xb0, 0x09, 0x9a, 0x6d, 0x39, 0xe7, 0xba, 0x9c, 0x58,
-	0xd7, 0xd6, 0x74, 0xa6, 0x2b, 0xe4, 0x18, 0x9a, 0xf9, 0x55, 0xec, 0x40, 0x73, 0x3a, 0xbe, 0xb0,
-	0x9c, 0xd9, 0xe4, 0x54, 0x97, 0xb0, 0x0b, 0x2d, 0xcb, 0x71, 0x2e, 0x67, 0xd6, 0x6c, 0x7c, 0xa6,
-	0xcb, 0x08, 0x50, 0x77, 0xac, 0xd9, 0xe4, 0x66, 0xac, 0x2b, 0xe4, 0x87, 0x0c, 0xdd, 0xac, 0x7a,
-	0xb6, 0xc6, 0xc3, 0xd2, 0x6e, 0x76, 0x69, 0x29, 0xba, 0xb5, 0x1c, 0x31, 0xae, 0x52, 0x18, 0x17,
-	0x41, 0x5b, 0x7b, 0x51, 0xb2, 0x19, 0x75, 0xd8, 0x71, 0x85, 0x8d, 0x3a, 0xa8, 0x4b, 0x9e, 0x6f,
-	0x25, 0x31, 0xab, 0x47, 0x6a, 0x80, 0x6a, 0x5f, 0x26, 0x13, 0xb5, 0xa0, 0xf6, 0x66, 0xe2, 0x58,
-	0xb6, 0xae, 0x90, 0x3e, 0xa0, 0xed, 0x47, 0xf1, 0x59, 0xe8, 0x27, 0x54, 0xe6, 0xdc, 0x7f, 0x93,
-	0x61, 0xb7, 0xe4, 0xce, 0x3a, 0x7f, 0x09, 0x8d, 0x79, 0xea, 0x32, 0xe4, 0x03, 0x75, 0xd8, 0x1e,
-	0xfd, 0x4f, 0x2b, 0x60, 0x34, 0x3d, 0x4f, 0x82, 0x8f, 0xdc, 0xcd, 0xf1, 0xe6, 0x09, 0xc0, 0xa3,
-	0x7b, 0x33, 0x99, 0x5c, 0x98, 0xac, 0xa0, 0x2e, 0xa5, 0xac, 0x2e, 0x02, 0x30, 0xbd, 0xb2, 0x73,
-	0x69, 0x6d, 0x08, 0x97, 0x0b, 0x84, 0x13, 0x1b, 0xda, 0x02, 0x93, 0x75, 0x4a, 0x40, 0x0d, 0xf9,
-	0x83, 0x80, 0xb4, 0x47, 0x3a, 0x2d, 0x84, 0xa8, 0xcb, 0x1f, 0xdc, 0x24, 0x68, 0xfe, 0x07, 0xaa,
-	0xcb, 0x1f, 0x92, 0x5e, 0x6e, 0xd9, 0x72, 0x29, 0x26, 0xea, 0xb8, 0xc2, 0x26, 0xaf, 0x60, 0x6f,
-	0x1a, 0x7b, 0x61, 0x7c, 0xca, 0xef, 0x56, 0x3c, 0x60, 0x41, 0x9c, 0x17, 0xcf, 0xf5, 0x2a, 0x17,
-	0xf4, 0x8a, 0xa0, 0xad, 0x78, 0x18, 0x8b, 0xae, 0x6b, 0xae, 0xb0, 0xc9, 0x73, 0x18, 0x6c, 0x27,
-	0xc8, 0x3a, 0xcb, 0xd1, 0x72, 0x01, 0x7d, 0x04, 0xfd, 0x69, 0xcc, 0x57, 0xff, 0x52, 0x8d, 0xec,
-	0x27, 0xad, 0x95, 0xb0, 0x69, 0x62, 0x72, 0xbe, 0x79, 0xb0, 0x6c, 0x9e, 0xae, 0x1a, 0x4d, 0x68,
-	0x26, 0xab, 0x5d, 0x7b, 0x8b, 0x3c, 0xc7, 0xe6, 0xfc, 0xe7, 0x75, 0x8f, 0x7e, 0x2a, 0x50, 0x1f,
-	0x07, 0x0b, 0x3f, 0x60, 0x48, 0xa1, 0x91, 0xe5, 0xc4, 0x1d, 0x5a, 0xfe, 0x20, 0x4c, 0x9d, 0x6e,
-	0xfd, 0x0f, 0x44, 0xc2, 0x21, 0xd4, 0x84, 0x9a, 0xb1, 0x5b, 0x7a, 0x71, 0x66, 0xaf, 0x2c, 0x72,
-	0x22, 0xe1, 0x28, 0x7b, 0x15, 0xef, 0xfd, 0xf8, 0x93, 0xcd, 0x17, 0xd1, 0x5f, 0x6f, 0xbc, 0x90,
-	0xf1, 0x04, 0xda, 0x05, 0xb9, 0xe1, 0x2e, 0xfd, 0x5d, 0xba, 0x66, 0xbf, 0x4a, 0x91, 0x44, 0xc2,
-	0x27, 0xa0, 0x4e, 0xaf, 0x6c, 0x6c, 0xd3, 0x47, 0x25, 0x99, 0x9d, 0xa2, 0x2e, 0x44, 0x85, 0x53,
-	0xe8, 0x95, 0x69, 0xc3, 0x01, 0xad, 0x14, 0x82, 0xb9, 0x4f, 0xab, 0xf9, 0x25, 0x12, 0xbe, 0x86,
-	0x6e, 0x89, 0x21, 0xdc, 0xa3, 0x55, 0xec, 0x9a, 0x03, 0x5a, 0x4d, 0xa4, 0xf4, 0xa1, 0x2e, 0x3e,
-	0xe5, 0xe3, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xcb, 0xc6, 0x0a, 0x40, 0xa1, 0x05, 0x00, 0x00,
+	// 2034 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x17, 0x45, 0x89, 0x92, 0x9e, 0x64, 0x85, 0x19, 0x2b, 0x8e, 0x4a, 0xec, 0x36, 0x0e, 0xb1,
+	0xdd, 0xb8, 0xd9, 0x5d, 0x62, 0xa1, 0x5d, 0xa0, 0xd8, 0x16, 0x45, 0x57, 0xb5, 0x95, 0x54, 0x88,
+	0x2c, 0x39, 0x23, 0x39, 0x69, 0x4f, 0x02, 0x23, 0x8d, 0x1d, 0x22, 0x14, 0xc9, 0x90, 0x54, 0x5c,
+	0x7f, 0x80, 0xde, 0x7a, 0xec, 0xa1, 0x45, 0x4f, 0xbd, 0xf7, 0x54, 0xa0, 0xc7, 0x7e, 0x98, 0x9e,
+	0x8a, 0x7e, 0x8b, 0xe2, 0xbd, 0x19, 0x4a, 0xa4, 0xac, 0x22, 0x7b, 0x9b, 0xdf, 0x9b, 0xc7, 0x99,
+	0xf7, 0x6f, 0xde, 0xfc, 0x86, 0xd0, 0x70, 0x23, 0xcf, 0x89, 0xe2, 0x30, 0x0d, 0x6d, 0x13, 0xda,
+	0xaf, 0x44, 0x9c, 0x78, 0x61, 0xc0, 0xc5, 0xfb, 0xb5, 0x48, 0x52, 0xfb, 0x0b, 0xb8, 0xb7, 0x91,
+	0x24, 0x51, 0x18, 0x24, 0x82, 0x75, 0xa1, 0xf6, 0x41, 0x8a, 0xba, 0xda, 0xb1, 0x76, 0xd2, 0xe0,
+	0x19, 0xb4, 0xff, 0x5c, 0x86, 0xd6, 0x85, 0x1b, 0x27, 0x42, 0x7d, 0xcd, 0x3e, 0x87, 0xca, 0x3b,
+	0x2f, 0x58, 0x92, 0x5e, 0xbb, 0xc7, 0x9c, 0xfc, 0xa4, 0xf3, 0xc2, 0x0b, 0x96, 0x9c, 0xe6, 0x19,
+	0x83, 0x4a, 0xe0, 0xae, 0x44, 0xb7, 0x4c, 0xeb, 0xd1, 0x18, 0xb7, 0x59, 0x84, 0x41, 0x2a, 0x82,
+	0xb4, 0xab, 0x1f, 0x6b, 0x27, 0x2d, 0x9e, 0x41, 0xd4, 0xf6, 0xdd, 0xe0, 0xba, 0x5b, 0x91, 0xda,
+	0x38, 0x66, 0x1d, 0xa8, 0xbe, 0x5f, 0x8b, 0xf8, 0xb6, 0x5b, 0x25, 0xa1, 0x04, 0xec, 0x29, 0x54,
+	0x56, 0xe1, 0x52, 0x74, 0x0d, 0xda, 0xff, 0xa8, 0xb8, 0xff, 0xa5, 0x9b, 0xa4, 0xe7, 0xe1, 0x52,
+	0x70, 0xd2, 0xb1, 0x9f, 0x40, 0x05, 0x2d, 0x62, 0x4d, 0xa8, 0x0d, 0xc7, 0xaf, 0xfa, 0xa3, 0xe1,
+	0x99, 0x59, 0x62, 0x75, 0xa8, 0x8c, 0xfa, 0xe3, 0xe7, 0xa6, 0x86, 0xa3, 0xcb, 0xfe, 0x74, 0x66,
+	0x96, 0xed, 0x6f, 0xa0, 0x9e, 0x7d, 0xca, 0x5a, 0x50, 0x9f, 0x0e, 0xce, 0xfb, 0xe3, 0xd9, 0xf0,
+	0xd4, 0x2c, 0xb1, 0x03, 0x68, 0xf4, 0xc7, 0xe3, 0xc9, 0xac, 0x3f, 0x1b, 0x9c, 0x99, 0x1a, 0x03,
+	0x30, 0xc6, 0xfd, 0xd9, 0xf0, 0xd5, 0xc0, 0x2c, 0xdb, 0x7f, 0xd5, 0xe0, 0x40, 0xed, 0xae, 0xc2,
+	0xf8, 0xa4, 0x10, 0x9b, 0x43, 0xa7, 0x30, 0xbb, 0x13, 0x1c, 0x72, 0xb7, 0x9c, 0x73, 0x97, 0x41,
+	0x65, 0xed, 0x26, 0x18, 0x19, 0xfd, 0xa4, 0xc5, 0x69, 0xcc, 0x4c, 0xd0, 0xfd, 0x30, 0x8b, 0x0a,
+	0x0e, 0xf7, 0xbb, 0x54, 0x03, 0x7d, 0x34, 0x41, 0x8f, 0x1a, 0x50, 0x7d, 0x36, 0x1c, 0xf7, 0x47,
+	0x66, 0xd9, 0xfe, 0x2d, 0xdc, 0xa7, 0xed, 0x7f, 0xed, 0xa6, 0x8b, 0xb7, 0x59, 0xf2, 0x1e, 0x43,
+	0xe5, 0xca, 0xf3, 0x05, 0x19, 0xd8, 0xec, 0x1d, 0x14, 0x82, 0xc7, 0x69, 0x8a, 0x1d, 0x43, 0x33,
+	0x72, 0x63, 0xd7, 0xf7, 0x85, 0xef, 0x25, 0x2b, 0xb2, 0xb0, 0xca, 0xf3, 0x22, 0xfb, 0x0a, 0x58,
+	0x7e, 0x65, 0xe5, 0x7b, 0x96, 0x6f, 0x2d, 0x97, 0xef, 0xcf, 0xc1, 0x88, 0x45, 0xb2, 0xf6, 0x53,
+	0x5a, 0xa6, 0xd9, 0x6b, 0x17, 0x23, 0xc2, 0xd5, 0x2c, 0x66, 0x5a, 0xc4, 0x71, 0x18, 0x53, 0x55,
+	0x34, 0xb8, 0x04, 0xf6, 0x97, 0xc0, 0x46, 0x5e, 0x92, 0x9e, 0xc5, 0x1e, 0x16, 0x63, 0xe6, 0xc2,
+	0x11, 0x18, 0x49, 0xea, 0xa6, 0xeb, 0x84, 0x76, 0xaa, 0x73, 0x85, 0xec, 0x7f, 0x18, 0x70, 0x58,
+	0x50, 0x57, 0x76, 0x7d, 0x07, 0xb5, 0xa5, 0x14, 0x75, 0xb5, 0x63, 0xfd, 0xa4, 0xd9, 0x7b, 0xe4,
+	0xec, 0x51, 0x73, 0x24, 0x1e, 0x06, 0x57, 0x21, 0xcf, 0xf4, 0xad, 0xbf, 0x69, 0xd0, 0xb8, 0x08,
+	0x43, 0x7f, 0x9a, 0xba, 0xa9, 0xc0, 0x8d, 0x6f, 0xdc, 0x20, 0x15, 0x32, 0xbd, 0x55, 0xae, 0x10,
+	0x16, 0x75, 0xbc, 0x0e, 0x02, 0x4f, 0xa5, 0xb3, 0xca, 0x33, 0x88, 0x33, 0x37, 0xae, 0x97, 0xe2,
+	0x8c, 0x2e, 0x67, 0x14, 0xc4, 0x99, 0x64, 0xbd, 0x58, 0x88, 0x24, 0xa1, 0xdc, 0x56, 0x79, 0x06,
+	0x71, 0x17, 0xf2, 0x3e, 0xa1, 0xaa, 0xaf, 0x72, 0x85, 0x48, 0xfe, 0x7b, 0x0f, 0x77, 0x37, 0x94,
+	0x9c, 0x90, 0xf5, 0x07, 0x0d, 0x0e, 0x86, 0x41, 0x92, 0xba, 0xc1, 0x42, 0x48, 0x3b, 0xdb, 0x50,
+	0xf6, 0x96, 0x2a, 0x0d, 0x65, 0x6f, 0x89, 0xc1, 0xf5, 0x56, 0xee, 0x75, 0x76, 0x12, 0x25, 0xc8,
+	0x85, 0x51, 0xc6, 0x5c, 0x21, 0x3a, 0xa2, 0xb1, 0x70, 0x71, 0x23, 0xb4, 0x4c, 0xe7, 0x19, 0x64,
+	0x9f, 0x40, 0x23, 0x8a, 0x43, 0x34, 0x52, 0xa0, 0x71, 0xfa, 0x49, 0x95, 0x6f, 0x05, 0xd6, 0xbf,
+	0xcb, 0x00, 0xdb, 0x18, 0x6e, 0x0a, 0x5c, 0xcb, 0x15, 0x78, 0xae, 0xc9, 0x94, 0x0b, 0x4d, 0x86,
+	0x3d, 0x83, 0x83, 0x45, 0x18, 0x5c, 0x79, 0xd7, 0xf3, 0x9c, 0x4d, 0xed, 0xde, 0xe3, 0xbd, 0x99,
+	0x3a, 0x25, 0xcd, 0x29, 0x29, 0xf2, 0xd6, 0x22, 0x87, 0xd8, 0x4f, 0xa0, 0xad, 0xd6, 0xc9, 0x36,
+	0x92, 0x27, 0x47, 0xad, 0xae, 0xba, 0x1e, 0x7b, 0x0c, 0xea, 0xb3, 0xb9, 0x0c, 0x4c, 0x9d, 0x94,
+	0x9a, 0x52, 0x36, 0xa4, 0xf0, 0xf4, 0xa0, 0x12, 0x85, 0xa1, 0x4f, 0x49, 0x68, 0xf6, 0x7e, 0xbc,
+	0xd7, 0x90, 0x4d, 0x69, 0x70, 0xd2, 0x65, 0xdf, 0x43, 0xc3, 0x53, 0x99, 0x48, 0xba, 0x06, 0xd5,
+	0x9a, 0xbd, 0xf7, 0xc3, 0x42, 0xbe, 0xf8, 0xf6, 0x23, 0xf6, 0x29, 0x80, 0xef, 0x26, 0xe9, 0x5c,
+	0x1e, 0x86, 0x1a, 0x99, 0xd5, 0x40, 0xc9, 0x80, 0x0e, 0xc4, 0x05, 0xb4, 0xf2, 0xce, 0x63, 0x6f,
+	0xba, 0x1c, 0x9f, 0xf7, 0xc7, 0xfd, 0xe7, 0x03, 0xec, 0x02, 0x06, 0x94, 0x27, 0x2f, 0x4c, 0x0d,
+	0x1b, 0xd8, 0xe4, 0x72, 0x76, 0x46, 0x1d, 0xab, 0x8c, 0x8d, 0xe2, 0x7c, 0x38, 0x9d, 0x0e, 0xc7,
+	0xcf, 0x4d, 0x9d, 0xb5, 0x01, 0x2e, 0xc7, 0x67, 0x83, 0xd3, 0x51, 0x9f, 0x0f, 0xce, 0xcc, 0x8a,
+	0xfd, 0x0e, 0x3a, 0x64, 0x8c, 0xef, 0x4b, 0x1b, 0xb3, 0x43, 0xb6, 0x2f, 0x7d, 0x4f, 0xe0, 0x1e,
+	0x85, 0x6b, 0x1e, 0x8b, 0x2b, 0x11, 0x8b, 0x60, 0x91, 0x55, 0x54, 0x9b, 0xc4, 0x3c, 0x93, 0x62,
+	0x69, 0xad, 0xa3, 0xa5, 0x9b, 0x0a, 0x4a, 0x63, 0x9d, 0x2b, 0x64, 0x3f, 0x84, 0x07, 0x3b, 0x9b,
+	0xc9, 0x78, 0xd8, 0x3f, 0x85, 0x43, 0x2e, 0x56, 0xe1, 0x07, 0xf1, 0x51, 0x23, 0xec, 0x23, 0xe8,
+	0x14, 0x55, 0xd5, 0x12, 0x7d, 0x80, 0xe9, 0xcb, 0x51, 0xf6, 0xe5, 0xe6, 0xe6, 0xd0, 0xf2, 0x37,
+	0xc7, 0xa7, 0x00, 0xa9, 0xb7, 0x12, 0xe1, 0x3a, 0x9d, 0xaf, 0x12, 0xb2, 0x5d, 0xe7, 0x0d, 0x25,
+	0x39, 0x4f, 0xec, 0x3f, 0x95, 0xa1, 0x49, 0x6b, 0xa8, 0xc6, 0x61, 0x83, 0x1e, 0x87, 0x37, 0xaa,
+	0x55, 0x9a, 0x4e, 0x6e, 0xca, 0xe1, 0xe1, 0x0d, 0xc7, 0x49, 0xf6, 0x05, 0x18, 0x6f, 0x85, 0xbb,
+	0x14, 0xb1, 0x6a, 0x70, 0x87, 0x05, 0xb5, 0xdf, 0xd0, 0x14, 0x57, 0x2a, 0xd6, 0x08, 0x8c, 0xd3,
+	0xd0, 0x5f, 0xaf, 0x82, 0xbd, 0xbd, 0x92, 0x41, 0x25, 0xbd, 0x8d, 0x36, 0xf7, 0x25, 0x8e, 0x99,
+	0x05, 0xf5, 0x60, 0xed, 0xfb, 0xee, 0x1b, 0x3f, 0x8b, 0xe5, 0x06, 0x5b, 0x3f, 0x03, 0x43, 0xae,
+	0xcf, 0xbe, 0xc2, 0x5b, 0x15, 0xd7, 0xcd, 0x3a, 0x5c, 0xd1, 0x0a, 0xb9, 0x27, 0xcf, 0x74, 0xac,
+	0xaf, 0x40, 0xe7, 0xe1, 0x0d, 0xee, 0xb7, 0x10, 0xbe, 0x4f, 0x9f, 0xb4, 0x38, 0x8d, 0xc9, 0xae,
+	0xb5, 0xef, 0x77, 0xcb, 0xc7, 0xfa, 0x49, 0x9d, 0xd3, 0xd8, 0xee, 0xc8, 0x2e, 0xfc, 0x72, 0x2d,
+	0x62, 0x4f, 0x64, 0x5d, 0xd8, 0xfe, 0x8f, 0x06, 0x87, 0x05, 0xb1, 0x0a, 0xda, 0xb7, 0x50, 0x7b,
+	0x2f, 0x45, 0xca, 0x16, 0xcb, 0xd9, 0xa3, 0xe6, 0x20, 0xbe, 0xe5, 0x99, 0xaa, 0xf5, 0x17, 0x0d,
+	0xaa, 0x24, 0xca, 0x35, 0x2f, 0x9d, 0x9a, 0x17, 0x5e, 0x8a, 0x89, 0x0a, 0x6f, 0x83, 0xd3, 0x18,
+	0x65, 0x6f, 0xc3, 0x24, 0x55, 0x8d, 0x8b, 0xc6, 0x92, 0x59, 0xac, 0x56, 0x6e, 0xb0, 0x54, 0x47,
+	0x3e, 0x83, 0x14, 0x57, 0x6f, 0x25, 0xe8, 0x24, 0xeb, 0x9c, 0xc6, 0x58, 0x1f, 0xd8, 0x68, 0x24,
+	0x89, 0x68, 0x70, 0x09, 0xb6, 0x55, 0x53, 0xcb, 0x55, 0x8d, 0x6d, 0x83, 0xf9, 0xc2, 0xf3, 0x7d,
+	0x69, 0xb1, 0xaa, 0xaf, 0x1d, 0x2b, 0xed, 0x43, 0xb8, 0x9f, 0xd3, 0x51, 0x25, 0xf9, 0x2b, 0x78,
+	0x30, 0x4d, 0xdd, 0x38, 0x3d, 0x0d, 0x57, 0x51, 0x18, 0x88, 0x20, 0xcd, 0xd5, 0xf5, 0xbe, 0xec,
+	0x47, 0x61, 0x9c, 0xaa, 0x1b, 0x84, 0xc6, 0xf6, 0x00, 0x8e, 0x76, 0x17, 0xd8, 0xde, 0xb5, 0xa4,
+	0xad, 0x6d, 0xb5, 0xd9, 0x43, 0xa8, 0x61, 0x24, 0xe6, 0x5e, 0xa4, 0x82, 0x65, 0x20, 0x1c, 0x46,
+	0xf6, 0x53, 0xe8, 0x4c, 0xd3, 0x30, 0xfa, 0x21, 0x66, 0xe0, 0x11, 0xdd, 0xd1, 0x55, 0xce, 0x3c,
+	0xdf, 0x70, 0x46, 0xb1, 0x94, 0x47, 0x0f, 0x8b, 0x13, 0x8f, 0xe4, 0xda, 0xbd, 0xce, 0xd6, 0xd8,
+	0xe0, 0xff, 0xdf, 0xea, 0xed, 0x7b, 0x70, 0xa0, 0x5a, 0xb7, 0xaa, 0xa4, 0xff, 0x56, 0xa0, 0x9d,
+	0x49, 0x3e, 0xc6, 0x46, 0xe9, 0x46, 0x0d, 0xe3, 0x77, 0x4b, 0x2f, 0xab, 0x88, 0x0c, 0x6e, 0xdc,
+	0x0f, 0x37, 0x17, 0x1a, 0xc2, 0x09, 0x5d, 0x9c, 0xb2, 0xb1, 0xab, 0xc2, 0x50, 0x88, 0x7d, 0x07,
+	0xb0, 0xc8, 0xdc, 0x94, 0xf7, 0x59, 0xb3, 0xf7, 0x23, 0xa7, 0x68, 0x89, 0xb3, 0x0d, 0x44, 0x4e,
+	0x19, 0x6f, 0x42, 0xdc, 0x36, 0x89, 0xdc, 0x45, 0x56, 0x42, 0x5b, 0x81, 0xb5, 0x80, 0xca, 0xc5,
+	0x4e, 0x42, 0xb4, 0x7c, 0x42, 0xd8, 0x23, 0x68, 0x46, 0xeb, 0x37, 0xbe, 0xb7, 0x98, 0xe7, 0x52,
+	0x0e, 0x52, 0x44, 0x5f, 0x3e, 0x86, 0x56, 0x14, 0x7b, 0x1f, 0xdc, 0x54, 0x48, 0x0d, 0x5d, 0x71,
+	0x30, 0x29, 0x43, 0x15, 0x2b, 0x05, 0x63, 0xe4, 0xad, 0xbc, 0x34, 0xa1, 0x73, 0x1c, 0x29, 0x36,
+	0xa4, 0x71, 0x1a, 0xa3, 0xcf, 0x2b, 0xb1, 0x0a, 0xe3, 0x5b, 0xd5, 0xe5, 0x14, 0xc2, 0x9d, 0xe5,
+	0x68, 0x9e, 0xdc, 0xb8, 0x11, 0xad, 0xab, 0x73, 0x90, 0xa2, 0xe9, 0x8d, 0x1b, 0x61, 0x8b, 0x8c,
+	0xbc, 0x65, 0x32, 0xf7, 0x71, 0x6d, 0x45, 0x00, 0x1a, 0x28, 0xa1, 0xcd, 0xac, 0x7f, 0x69, 0xd0,
+	0xd8, 0x84, 0x64, 0x6f, 0x1d, 0xef, 0x27, 0x1b, 0x9b, 0xf3, 0xa6, 0xef, 0x9c, 0xb7, 0x58, 0xb8,
+	0xcb, 0x5b, 0xda, 0xa7, 0xce, 0x25, 0x60, 0x4f, 0xa1, 0x8a, 0x4e, 0x67, 0x29, 0xe9, 0xec, 0xa6,
+	0x04, 0xdd, 0xe7, 0x52, 0x85, 0x39, 0x60, 0x90, 0xa5, 0x09, 0x65, 0xa1, 0xd9, 0x3b, 0xda, 0x55,
+	0x96, 0x31, 0xe2, 0x4a, 0xcb, 0x5e, 0x41, 0x73, 0x14, 0x5e, 0x6f, 0xa8, 0xe4, 0x27, 0xd0, 0xd8,
+	0x64, 0x55, 0x79, 0xb1, 0x15, 0x60, 0x10, 0xaf, 0x42, 0xdf, 0x0f, 0x6f, 0xc8, 0x97, 0x3a, 0x57,
+	0x88, 0x9c, 0xf1, 0x82, 0xc5, 0xd6, 0x19, 0x04, 0xd4, 0x66, 0x5c, 0xcf, 0x57, 0x74, 0x8e, 0xc6,
+	0x76, 0x0c, 0x2d, 0xb9, 0x9d, 0xaa, 0xeb, 0x2f, 0x91, 0x73, 0xc5, 0xc2, 0x5d, 0xa9, 0x07, 0x42,
+	0xc7, 0xc9, 0x4f, 0x3b, 0x53, 0x9a, 0xe3, 0x4a, 0x07, 0x57, 0x5c, 0xba, 0xa9, 0x4b, 0xbb, 0xb7,
+	0x38, 0x8d, 0xed, 0x63, 0x30, 0xa4, 0x16, 0x3e, 0x44, 0xa6, 0xb3, 0xb3, 0xc9, 0xe5, 0xcc, 0x2c,
+	0xa9, 0xf1, 0x80, 0x73, 0x53, 0xb3, 0x1d, 0x60, 0xaf, 0x91, 0x97, 0x0f, 0x3e, 0x60, 0xa9, 0x66,
+	0x9e, 0x76, 0xa1, 0xf6, 0xd6, 0x4b, 0xd2, 0x50, 0x5d, 0x89, 0x75, 0x9e, 0x41, 0xfb, 0xef, 0x3a,
+	0x54, 0x49, 0x37, 0xd7, 0xd4, 0x2a, 0xd4, 0x7a, 0x1f, 0xe5, 0x2e, 0xa4, 0x76, 0xaf, 0xe9, 0x90,
+	0x96, 0x33, 0xbb, 0x8d, 0x84, 0xba, 0x9d, 0xb2, 0xce, 0xaa, 0xe7, 0x3a, 0x6b, 0x21, 0xa4, 0x95,
+	0xdd, 0x90, 0x6e, 0xaa, 0xa3, 0xba, 0x53, 0x1d, 0x92, 0xf0, 0x18, 0x39, 0xf6, 0xcf, 0x3e, 0x03,
+	0xc3, 0x77, 0x6f, 0x91, 0xb6, 0xd7, 0xa8, 0x10, 0x5a, 0xca, 0x80, 0x11, 0x0a, 0xb9, 0x9a, 0xb3,
+	0xe6, 0x50, 0x25, 0xc1, 0x1d, 0xd6, 0xbb, 0xe5, 0xb7, 0xe5, 0x3b, 0xfc, 0x76, 0x1d, 0xc7, 0xd9,
+	0x13, 0x54, 0xe7, 0x19, 0x44, 0x33, 0xd2, 0x30, 0x75, 0x7d, 0x55, 0xf6, 0x12, 0xd8, 0x7f, 0xd4,
+	0xa0, 0x82, 0x3e, 0x23, 0x8f, 0xba, 0x1c, 0xbf, 0x18, 0x4f, 0x5e, 0x8f, 0xcd, 0x12, 0x82, 0x8b,
+	0xcb, 0xd1, 0x68, 0x48, 0xcf, 0x48, 0x00, 0x03, 0x41, 0xc6, 0xb6, 0x4e, 0xf9, 0x80, 0xa8, 0x97,
+	0x8e, 0x60, 0x3a, 0xeb, 0x73, 0x04, 0x15, 0x7c, 0x9a, 0xf1, 0x41, 0xff, 0xec, 0x77, 0x66, 0x15,
+	0x79, 0xdb, 0x78, 0x32, 0x9b, 0x4b, 0x68, 0xe0, 0xf7, 0xcf, 0xfa, 0x43, 0xfc, 0xbe, 0x86, 0x9f,
+	0xf0, 0xc1, 0xf9, 0xe4, 0xd5, 0xe0, 0xcc, 0xac, 0xb3, 0xfb, 0x70, 0x80, 0x0b, 0xcf, 0x2f, 0xf8,
+	0xe4, 0x39, 0x1f, 0x4c, 0xa7, 0x66, 0xa3, 0xf7, 0x4f, 0x03, 0x8c, 0x41, 0x70, 0xed, 0x05, 0x82,
+	0x39, 0x50, 0xcb, 0x08, 0xed, 0x3d, 0xa7, 0xf8, 0xc4, 0xb7, 0x4c, 0x67, 0xe7, 0x85, 0x6f, 0x97,
+	0xd8, 0x09, 0x54, 0xe9, 0xf5, 0xc5, 0x8a, 0xcf, 0x3e, 0x6b, 0xe7, 0x51, 0x66, 0x97, 0x58, 0x4f,
+	0xbd, 0x6b, 0x5f, 0x7b, 0xe9, 0x5b, 0xac, 0xd0, 0x8f, 0x7e, 0xf1, 0xb5, 0xc6, 0x7e, 0x01, 0xb0,
+	0x7d, 0x14, 0x32, 0xe6, 0x6c, 0x41, 0xf6, 0xd5, 0xa1, 0x73, 0xf7, 0xd5, 0x68, 0x97, 0x4e, 0xb4,
+	0xaf, 0x35, 0xf6, 0x73, 0x68, 0xe6, 0x78, 0x32, 0x3b, 0x74, 0xee, 0xbe, 0xfb, 0xac, 0xce, 0x3e,
+	0x2a, 0x6d, 0x97, 0xd8, 0xf7, 0x70, 0x50, 0x60, 0x95, 0xec, 0x81, 0xb3, 0x8f, 0xd2, 0x5a, 0x47,
+	0xce, 0x7e, 0xf2, 0x59, 0x62, 0xbf, 0x84, 0x56, 0x9e, 0x53, 0xb2, 0x8e, 0xb3, 0x87, 0x8d, 0x5a,
+	0x0f, 0x9c, 0xbd, 0xc4, 0xb3, 0xc4, 0x3e, 0x03, 0x7d, 0xfa, 0x72, 0xc4, 0x9a, 0xce, 0x96, 0x80,
+	0x5a, 0xad, 0x3c, 0x03, 0xb3, 0x4b, 0x5b, 0x17, 0x15, 0x11, 0x52, 0x2e, 0x16, 0x49, 0x95, 0xd5,
+	0x29, 0x0a, 0x37, 0x3b, 0x7c, 0x0b, 0x8d, 0x0d, 0xbd, 0x60, 0xf7, 0x9d, 0x5d, 0x3a, 0x62, 0x31,
+	0xe7, 0x2e, 0xfb, 0x28, 0xb1, 0x53, 0x68, 0x17, 0xe9, 0x03, 0x3b, 0x72, 0x8a, 0x82, 0xec, 0xfb,
+	0x87, 0xce, 0x7e, 0x9e, 0x21, 0xa3, 0x5b, 0x20, 0x04, 0xec, 0x81, 0xb3, 0x8f, 0x4c, 0x58, 0x47,
+	0xce, 0x7e, 0xde, 0x50, 0x42, 0x8a, 0xac, 0x9e, 0x2b, 0x6d, 0xa7, 0x70, 0xf3, 0x5b, 0xf7, 0x76,
+	0xba, 0xb5, 0x5d, 0xc2, 0x1f, 0x28, 0x54, 0x70, 0x2d, 0x27, 0xd7, 0xa7, 0xad, 0x83, 0x42, 0x9f,
+	0xa4, 0x70, 0x3a, 0xd0, 0xcc, 0xb5, 0x39, 0x76, 0xe8, 0xdc, 0x6d, 0x7a, 0x96, 0x21, 0x3b, 0x06,
+	0xea, 0xbf, 0x31, 0xe8, 0x67, 0xd8, 0x37, 0xff, 0x1b, 0x00, 0xba, 0x47, 0xb8, 0xaa, 0x19, 0x13,
+	0x00, 0x00,
 }
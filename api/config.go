@@ -2,40 +2,292 @@ package api
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	units "github.com/docker/go-units"
 	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
 
 	yaml "gopkg.in/yaml.v2"
 )
 
+// DefaultHostIP is the host address the public ports are bound to by
+// default, so the components are not reachable from other hosts
+const DefaultHostIP = "127.0.0.1"
+
+// DefaultReadyTimeout is the time a component has to become ready after it
+// is started, by default
+const DefaultReadyTimeout = 5 * time.Minute
+
 // Config holds the config.yml file values
 type Config struct {
+	// HostIP is the default host address the public ports of the components
+	// are bound to
+	HostIP string `yaml:"host_ip"`
+
+	// ReadyTimeout is the time a component has to become ready after it is
+	// started, before its dependants are started
+	ReadyTimeout time.Duration `yaml:"ready_timeout,omitempty"`
+
 	Components struct {
 		Bblfshd struct {
+			ComponentOverrides `yaml:",inline"`
+
 			// Port is the public exposed port for this component's container
 			Port int
+			// HostIP is the host address the port is bound to, by default
+			// the global HostIP
+			HostIP string `yaml:"host_ip"`
 		}
 
 		BblfshWeb struct {
+			ComponentOverrides `yaml:",inline"`
+
 			// Port is the public exposed port for this component's container
 			Port int
+			// HostIP is the host address the port is bound to, by default
+			// the global HostIP
+			HostIP string `yaml:"host_ip"`
 		} `yaml:"bblfsh_web"`
 
 		GitbaseWeb struct {
+			ComponentOverrides `yaml:",inline"`
+
 			// Port is the public exposed port for this component's container
 			Port int
+			// HostIP is the host address the port is bound to, by default
+			// the global HostIP
+			HostIP string `yaml:"host_ip"`
+			// SelectLimit is the default limit of the rows returned by the
+			// queries, 0 means no limit
+			SelectLimit int `yaml:"select_limit,omitempty"`
 		} `yaml:"gitbase_web"`
 
 		Gitbase struct {
+			ComponentOverrides `yaml:",inline"`
+
 			// Port is the public exposed port for this component's container
 			Port int
+			// HostIP is the host address the port is bound to, by default
+			// the global HostIP
+			HostIP string `yaml:"host_ip"`
 		}
 
+		// MysqlCli is the MySQL client run by srcd sql
+		MysqlCli struct {
+			ComponentOverrides `yaml:",inline"`
+		} `yaml:"mysql_cli,omitempty"`
+
 		Daemon struct {
 			// Port is the public exposed port for the daemon container
 			Port int
+			// HostIP is the host address the port is bound to, by default
+			// the global HostIP
+			HostIP string `yaml:"host_ip"`
 		}
+
+		// Custom are the components declared by the user, by name. They are
+		// managed by the daemon like the built-in ones
+		Custom map[string]CustomComponent `yaml:",inline"`
+	}
+
+	// Drivers is the list of bblfsh drivers that must be installed. If it is
+	// not empty, any other driver will be removed from bblfshd
+	Drivers []Driver `yaml:",omitempty"`
+
+	// SQL holds the limits applied to the queries run through the daemon.
+	// A zero value means no limit
+	SQL struct {
+		// MaxConcurrentQueries is the maximum number of queries running at
+		// the same time through the daemon. Other gitbase clients, like
+		// gitbase-web, are not limited.
+		MaxConcurrentQueries int `yaml:"max_concurrent_queries,omitempty"`
+		// MaxRows is the maximum number of rows returned by a query run
+		// through the daemon. Other gitbase clients are not limited.
+		MaxRows int `yaml:"max_rows,omitempty"`
+		// MaxExecutionTime is the maximum time a query can run, from any
+		// gitbase client
+		MaxExecutionTime time.Duration `yaml:"max_execution_time,omitempty"`
+	} `yaml:",omitempty"`
+}
+
+// Driver is a bblfsh language driver declared in the config file
+type Driver struct {
+	// Lang is the language supported by the driver
+	Lang string
+	// Version is the driver image tag. If it is empty any installed version
+	// is accepted, and latest only accepts a driver installed from that tag
+	Version string `yaml:",omitempty"`
+	// Image is the driver docker image, by default bblfsh/<lang>-driver
+	Image string `yaml:",omitempty"`
+}
+
+// ComponentOverrides are the settings of a built-in component that replace
+// the ones of the engine
+type ComponentOverrides struct {
+	// Image replaces the docker image of the component
+	Image string `yaml:",omitempty"`
+	// Version replaces the image tag of the component
+	Version string `yaml:",omitempty"`
+	// Env are environment variables of the container. They replace the ones
+	// set by the engine with the same name
+	Env map[string]string `yaml:",omitempty"`
+	// Args are arguments appended to the ones set by the engine for the
+	// container command. If the engine sets none, they replace the default
+	// arguments of the image
+	Args []string `yaml:",omitempty"`
+
+	Resources `yaml:",inline"`
+}
+
+// Options returns the docker options to set the environment variables, the
+// arguments and the resource limits of the component container
+func (o ComponentOverrides) Options() []docker.ConfigOption {
+	opts := []docker.ConfigOption{
+		docker.WithEnvMap(o.Env),
+		docker.WithLimits(o.Limits()),
+	}
+	if len(o.Args) > 0 {
+		opts = append(opts, docker.WithCmd(o.Args...))
 	}
+
+	return opts
+}
+
+// apply replaces the image and version of the component
+func (o ComponentOverrides) apply(c *components.Component) {
+	if o.Image != "" {
+		c.Image = o.Image
+	}
+
+	if o.Version != "" {
+		c.Version = o.Version
+	}
+}
+
+// Resources are the resource limits of a component container, a zero value
+// means no limit
+type Resources struct {
+	// CPUs is the number of CPUs the container can use, like 1.5
+	CPUs float64 `yaml:"cpus,omitempty"`
+	// Memory is the maximum memory of the container, like 512m or 2g
+	Memory ByteSize `yaml:",omitempty"`
+	// MemorySwap is the maximum memory plus swap of the container, -1 means
+	// unlimited swap
+	MemorySwap ByteSize `yaml:"memory_swap,omitempty"`
+	// PidsLimit is the maximum number of processes of the container
+	PidsLimit int64 `yaml:"pids_limit,omitempty"`
+}
+
+// Limits returns the docker limits of the resources
+func (r Resources) Limits() docker.Limits {
+	return docker.Limits{
+		CPUs:       r.CPUs,
+		Memory:     int64(r.Memory),
+		MemorySwap: int64(r.MemorySwap),
+		PidsLimit:  r.PidsLimit,
+	}
+}
+
+// ByteSize is a size in bytes, written in the config file like the docker
+// memory options, as a number of bytes or with a unit: 512m, 2g
+type ByteSize int64
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface
+func (b *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	if s == "-1" {
+		*b = -1
+		return nil
+	}
+
+	size, err := units.RAMInBytes(s)
+	if err != nil {
+		return fmt.Errorf("invalid size %q, use a number of bytes or a unit like 512m or 2g", s)
+	}
+
+	*b = ByteSize(size)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface
+func (b ByteSize) MarshalYAML() (interface{}, error) {
+	return int64(b), nil
+}
+
+// CustomComponent is a component declared in the config file
+type CustomComponent struct {
+	// Image is the docker image of the component
+	Image string
+	// Version is the image tag, latest by default
+	Version string `yaml:",omitempty"`
+	// Ports are the ports of the container published in the host. The first
+	// one is used to check if the component is ready
+	Ports []CustomPort `yaml:",omitempty"`
+	// Env are the environment variables of the container
+	Env map[string]string `yaml:",omitempty"`
+	// Mounts are the host directories and docker volumes mounted in the
+	// container
+	Mounts []Mount `yaml:",omitempty"`
+	// Command replaces the default command of the image
+	Command []string `yaml:",omitempty"`
+	// Dependencies are the names of the components that must be ready
+	// before this one is started, like gitbase, bblfshd, or other components
+	// declared in the config file
+	Dependencies []string `yaml:",omitempty"`
+
+	Resources `yaml:",inline"`
+}
+
+// CustomPort is a port of a custom component published in the host
+type CustomPort struct {
+	// Port is the public port
+	Port int
+	// PrivatePort is the port in the container, by default the same as Port
+	PrivatePort int `yaml:"private_port,omitempty"`
+	// HostIP is the host address the port is bound to, by default the
+	// global HostIP
+	HostIP string `yaml:"host_ip,omitempty"`
+}
+
+// Mount is a host directory or a docker volume mounted in a container
+type Mount struct {
+	// Source is the host directory, if it is an absolute path, or the name
+	// of the docker volume, that is created if it does not exist
+	Source string
+	// Target is the path in the container
+	Target   string
+	ReadOnly bool `yaml:"read_only,omitempty"`
+}
+
+// builtinNames are the names of the built-in components, which can't be used
+// by the custom ones
+var builtinNames = []string{
+	"bblfshd", "bblfsh-web", "bblfsh_web",
+	"gitbase", "gitbase-web", "gitbase_web",
+	"daemon", "mysql-cli", "mysql_cli",
+}
+
+// ImageReference returns the driver image in the format image:tag
+func (d Driver) ImageReference() string {
+	image := d.Image
+	if image == "" {
+		image = fmt.Sprintf("bblfsh/%s-driver", d.Lang)
+	}
+
+	version := d.Version
+	if version == "" {
+		version = "latest"
+	}
+
+	return image + ":" + version
 }
 
 // SetDefaults fills the default values for any fields that are not set
@@ -59,6 +311,163 @@ func (c *Config) SetDefaults() {
 	if c.Components.Daemon.Port == 0 {
 		c.Components.Daemon.Port = components.DaemonPort
 	}
+
+	if c.HostIP == "" {
+		c.HostIP = DefaultHostIP
+	}
+
+	if c.ReadyTimeout == 0 {
+		c.ReadyTimeout = DefaultReadyTimeout
+	}
+
+	for _, hostIP := range []*string{
+		&c.Components.Bblfshd.HostIP,
+		&c.Components.BblfshWeb.HostIP,
+		&c.Components.GitbaseWeb.HostIP,
+		&c.Components.Gitbase.HostIP,
+		&c.Components.Daemon.HostIP,
+	} {
+		if *hostIP == "" {
+			*hostIP = c.HostIP
+		}
+	}
+
+	for i, d := range c.Drivers {
+		c.Drivers[i].Lang = strings.ToLower(d.Lang)
+	}
+
+	for name, cc := range c.Components.Custom {
+		if cc.Version == "" {
+			cc.Version = "latest"
+		}
+
+		for i, p := range cc.Ports {
+			if p.PrivatePort == 0 {
+				cc.Ports[i].PrivatePort = p.Port
+			}
+
+			if p.HostIP == "" {
+				cc.Ports[i].HostIP = c.HostIP
+			}
+		}
+
+		c.Components.Custom[name] = cc
+	}
+}
+
+// componentNameRegexp matches the valid names of the components declared in
+// the config. They are part of the container names, and can't contain
+// components.WorkspaceSeparator.
+var componentNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.-]*$`)
+
+// Validate returns an error if the components declared in the config are not
+// valid
+func (c *Config) Validate() error {
+	for name, cc := range c.Components.Custom {
+		if !componentNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid component name %s, only letters, digits, '.' and '-' are allowed", name)
+		}
+
+		for _, b := range builtinNames {
+			if name == b {
+				return fmt.Errorf("component %s is a built-in component", name)
+			}
+		}
+
+		if cc.Image == "" {
+			return fmt.Errorf("component %s has no image", name)
+		}
+
+		for _, m := range cc.Mounts {
+			if m.Source == "" || m.Target == "" {
+				return fmt.Errorf("component %s has a mount without source or target", name)
+			}
+		}
+
+		for _, d := range cc.Dependencies {
+			if !c.isComponent(d) {
+				return fmt.Errorf("component %s depends on unknown component %s", name, d)
+			}
+		}
+
+		if err := cc.Resources.validate(name); err != nil {
+			return err
+		}
+	}
+
+	builtins := map[string]Resources{
+		"bblfshd":     c.Components.Bblfshd.Resources,
+		"bblfsh_web":  c.Components.BblfshWeb.Resources,
+		"gitbase":     c.Components.Gitbase.Resources,
+		"gitbase_web": c.Components.GitbaseWeb.Resources,
+		"mysql_cli":   c.Components.MysqlCli.Resources,
+	}
+
+	for name, r := range builtins {
+		if err := r.validate(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validate checks that the limits of the named component can be applied
+func (r Resources) validate(name string) error {
+	if r.CPUs < 0 || r.Memory < 0 || r.MemorySwap < -1 || r.PidsLimit < 0 {
+		return fmt.Errorf("component %s has a negative resource limit", name)
+	}
+
+	if r.MemorySwap > 0 && r.MemorySwap < r.Memory {
+		return fmt.Errorf("component %s has a memory_swap lower than its memory", name)
+	}
+
+	if r.MemorySwap != 0 && r.Memory == 0 {
+		return fmt.Errorf("component %s has a memory_swap without memory", name)
+	}
+
+	return nil
+}
+
+// isComponent returns whether name is a built-in component that can be a
+// dependency, or a custom one
+func (c *Config) isComponent(name string) bool {
+	switch name {
+	case "bblfshd", "bblfsh-web", "gitbase", "gitbase-web":
+		return true
+	}
+
+	_, ok := c.Components.Custom[name]
+	return ok
+}
+
+// ApplyOverrides replaces the images and versions of the built-in components
+// of the components package with the ones set in the config
+func (c *Config) ApplyOverrides() {
+	c.Components.Bblfshd.apply(&components.Bblfshd)
+	c.Components.BblfshWeb.apply(&components.BblfshWeb)
+	c.Components.GitbaseWeb.apply(&components.GitbaseWeb)
+	c.Components.Gitbase.apply(&components.Gitbase)
+	c.Components.MysqlCli.apply(&components.MysqlCli)
+}
+
+// CustomComponents returns the components declared in the config file,
+// sorted by name
+func (c *Config) CustomComponents() []components.Component {
+	var names []string
+	for name := range c.Components.Custom {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	result := make([]components.Component, len(names))
+	for i, name := range names {
+		cc := c.Components.Custom[name]
+		result[i] = components.Custom(name, cc.Image, cc.Version)
+	}
+
+	return result
 }
 
 // AsYaml encodes config into yaml string
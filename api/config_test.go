@@ -0,0 +1,208 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const customConfig = `
+components:
+  gitbase:
+    port: 3307
+  analysis:
+    image: myorg/analysis
+    ports:
+      - port: 9000
+    env:
+      GITBASE_ADDR: srcd-cli-gitbase:3306
+    mounts:
+      - source: analysis-cache
+        target: /cache
+    dependencies: [gitbase, indexer]
+  indexer:
+    image: myorg/indexer
+    version: v1.2.0
+`
+
+func TestConfigCustomComponents(t *testing.T) {
+	require := require.New(t)
+
+	var c Config
+	require.NoError(yaml.UnmarshalStrict([]byte(customConfig), &c))
+	c.SetDefaults()
+	require.NoError(c.Validate())
+
+	require.Equal(3307, c.Components.Gitbase.Port)
+	require.Len(c.Components.Custom, 2)
+
+	analysis := c.Components.Custom["analysis"]
+	require.Equal("latest", analysis.Version)
+	require.Equal([]CustomPort{{Port: 9000, PrivatePort: 9000, HostIP: DefaultHostIP}}, analysis.Ports)
+	require.Equal([]string{"gitbase", "indexer"}, analysis.Dependencies)
+
+	cs := c.CustomComponents()
+	require.Len(cs, 2)
+	require.Equal("srcd-cli-analysis", cs[0].Name)
+	require.Equal("myorg/analysis:latest", cs[0].ImageWithVersion())
+	require.Equal("srcd-cli-indexer", cs[1].Name)
+
+	// the custom components are kept when the config is sent to the daemon
+	var sent Config
+	require.NoError(yaml.Unmarshal([]byte(c.AsYaml()), &sent))
+	require.Equal(c.Components.Custom, sent.Components.Custom)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := map[string]string{
+		"components:\n  analysis:\n    version: v1\n":                                  "component analysis has no image",
+		"components:\n  mysql-cli:\n    image: mysql\n":                                "component mysql-cli is a built-in component",
+		"components:\n  analysis:\n    image: a\n    dependencies: [unknown]\n":        "component analysis depends on unknown component unknown",
+		"components:\n  analysis:\n    image: a\n    mounts:\n      - target: /data\n": "component analysis has a mount without source or target",
+		"components:\n  my_analysis:\n    image: a\n":                                  "invalid component name my_analysis, only letters, digits, '.' and '-' are allowed",
+	}
+
+	for content, expected := range cases {
+		var c Config
+		require.NoError(t, yaml.UnmarshalStrict([]byte(content), &c))
+		require.EqualError(t, c.Validate(), expected)
+	}
+}
+
+const hostIPConfig = `
+host_ip: 0.0.0.0
+components:
+  gitbase:
+    host_ip: 192.168.1.10
+  analysis:
+    image: myorg/analysis
+    ports:
+      - port: 9000
+      - port: 9001
+        host_ip: "::"
+`
+
+func TestConfigHostIP(t *testing.T) {
+	require := require.New(t)
+
+	// the ports are only published in the loopback address by default
+	var def Config
+	def.SetDefaults()
+	require.Equal("127.0.0.1", def.HostIP)
+	require.Equal("127.0.0.1", def.Components.Bblfshd.HostIP)
+	require.Equal("127.0.0.1", def.Components.BblfshWeb.HostIP)
+	require.Equal("127.0.0.1", def.Components.Gitbase.HostIP)
+	require.Equal("127.0.0.1", def.Components.GitbaseWeb.HostIP)
+	require.Equal("127.0.0.1", def.Components.Daemon.HostIP)
+
+	var c Config
+	require.NoError(yaml.UnmarshalStrict([]byte(hostIPConfig), &c))
+	c.SetDefaults()
+	require.NoError(c.Validate())
+
+	require.Equal("0.0.0.0", c.HostIP)
+	require.Equal("192.168.1.10", c.Components.Gitbase.HostIP)
+	require.Equal("0.0.0.0", c.Components.Bblfshd.HostIP)
+	require.Equal("0.0.0.0", c.Components.GitbaseWeb.HostIP)
+	require.Equal("0.0.0.0", c.Components.Daemon.HostIP)
+	require.Equal([]CustomPort{
+		{Port: 9000, PrivatePort: 9000, HostIP: "0.0.0.0"},
+		{Port: 9001, PrivatePort: 9001, HostIP: "::"},
+	}, c.Components.Custom["analysis"].Ports)
+}
+
+const overridesConfig = `
+components:
+  gitbase:
+    image: myorg/gitbase
+    version: v0.20.0-patched
+    env:
+      GITBASE_CACHESIZE_MB: "1024"
+    args: ["--verbose"]
+  gitbase_web:
+    select_limit: 1000
+  mysql_cli:
+    version: "8.0"
+`
+
+func TestConfigOverrides(t *testing.T) {
+	require := require.New(t)
+
+	gitbase, gitbaseWeb, mysqlCli := components.Gitbase, components.GitbaseWeb, components.MysqlCli
+	defer func() {
+		components.Gitbase, components.GitbaseWeb, components.MysqlCli = gitbase, gitbaseWeb, mysqlCli
+	}()
+
+	var c Config
+	require.NoError(yaml.UnmarshalStrict([]byte(overridesConfig), &c))
+	c.SetDefaults()
+	require.NoError(c.Validate())
+	require.Empty(c.Components.Custom)
+
+	require.Equal(1000, c.Components.GitbaseWeb.SelectLimit)
+	require.Equal(map[string]string{"GITBASE_CACHESIZE_MB": "1024"}, c.Components.Gitbase.Env)
+	require.Equal([]string{"--verbose"}, c.Components.Gitbase.Args)
+	require.Len(c.Components.Gitbase.Options(), 3)
+	require.Len(c.Components.Bblfshd.Options(), 2)
+
+	c.ApplyOverrides()
+	require.Equal("myorg/gitbase:v0.20.0-patched", components.Gitbase.ImageWithVersion())
+	require.Equal(mysqlCli.Image+":8.0", components.MysqlCli.ImageWithVersion())
+	require.Equal(gitbaseWeb, components.GitbaseWeb)
+}
+
+const resourcesConfig = `
+components:
+  bblfshd:
+    memory: 2g
+    memory_swap: -1
+    pids_limit: 500
+  gitbase:
+    cpus: 1.5
+  analysis:
+    image: myorg/analysis
+    memory: 512m
+`
+
+func TestConfigResources(t *testing.T) {
+	require := require.New(t)
+
+	var c Config
+	require.NoError(yaml.UnmarshalStrict([]byte(resourcesConfig), &c))
+	c.SetDefaults()
+	require.NoError(c.Validate())
+
+	require.Equal(docker.Limits{Memory: 2 << 30, MemorySwap: -1, PidsLimit: 500},
+		c.Components.Bblfshd.Limits())
+	require.Equal(docker.Limits{CPUs: 1.5}, c.Components.Gitbase.Limits())
+	require.Equal(docker.Limits{Memory: 512 << 20}, c.Components.Custom["analysis"].Limits())
+	require.True(c.Components.GitbaseWeb.Limits().IsZero())
+
+	// the limits are kept when the config is sent to the daemon
+	var sent Config
+	require.NoError(yaml.Unmarshal([]byte(c.AsYaml()), &sent))
+	require.Equal(c.Components.Bblfshd.Resources, sent.Components.Bblfshd.Resources)
+	require.Equal(c.Components.Custom, sent.Components.Custom)
+}
+
+func TestConfigResourcesErrors(t *testing.T) {
+	cases := map[string]string{
+		"components:\n  gitbase:\n    cpus: -1\n":                        "component gitbase has a negative resource limit",
+		"components:\n  bblfshd:\n    memory: 2g\n    memory_swap: 1g\n": "component bblfshd has a memory_swap lower than its memory",
+		"components:\n  analysis:\n    image: a\n    memory_swap: 1g\n":  "component analysis has a memory_swap without memory",
+	}
+
+	for content, expected := range cases {
+		var c Config
+		require.NoError(t, yaml.UnmarshalStrict([]byte(content), &c))
+		require.EqualError(t, c.Validate(), expected)
+	}
+
+	var c Config
+	err := yaml.UnmarshalStrict([]byte("components:\n  gitbase:\n    memory: lots\n"), &c)
+	require.EqualError(t, err,
+		`invalid size "lots", use a number of bytes or a unit like 512m or 2g`)
+}
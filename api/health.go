@@ -0,0 +1,8 @@
+package api
+
+// Names of the grpc.health.v1 services that report the readiness of each
+// component. The empty service name reports the daemon itself.
+const (
+	HealthServiceBblfshd = "bblfshd"
+	HealthServiceGitbase = "gitbase"
+)
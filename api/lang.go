@@ -0,0 +1,32 @@
+package api
+
+import (
+	"strings"
+
+	enry "gopkg.in/src-d/enry.v1"
+)
+
+// driverLangs maps the languages detected by enry to the language of the
+// bblfsh driver that parses them, when the names differ
+var driverLangs = map[string]string{
+	"shell": "bash",
+	"c++":   "cpp",
+	"c#":    "csharp",
+}
+
+// DriverLang returns the name of the bblfsh driver language for a language
+// name, like bash for shell. The result is always lower case.
+func DriverLang(lang string) string {
+	lang = strings.ToLower(lang)
+	if l, ok := driverLangs[lang]; ok {
+		return l
+	}
+
+	return lang
+}
+
+// DetectLanguage returns the bblfsh driver language of a file, detected from
+// its name and content
+func DetectLanguage(name string, content []byte) string {
+	return DriverLang(enry.GetLanguage(name, content))
+}
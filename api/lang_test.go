@@ -0,0 +1,25 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriverLang(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("bash", DriverLang("Shell"))
+	require.Equal("cpp", DriverLang("C++"))
+	require.Equal("csharp", DriverLang("C#"))
+	require.Equal("go", DriverLang("Go"))
+	require.Equal("javascript", DriverLang("javascript"))
+}
+
+func TestDetectLanguage(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("bash", DetectLanguage("run.sh", []byte("#!/bin/bash\necho hi\n")))
+	require.Equal("cpp", DetectLanguage("main.cpp", []byte("int main() {}\n")))
+	require.Equal("python", DetectLanguage("hello.py", []byte("print('hi')\n")))
+}
@@ -0,0 +1,7 @@
+package api
+
+// IsNull returns whether the cell i of the row is NULL. Rows sent by older
+// servers don't have null markers, their cells are never reported as NULL.
+func (r *SQLResponse_Row) IsNull(i int) bool {
+	return i < len(r.GetNull()) && r.Null[i]
+}
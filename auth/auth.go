@@ -0,0 +1,329 @@
+// Package auth manages the credentials that secure the connection between the
+// srcd CLI and the srcd-server daemon: a local certificate authority, a server
+// certificate signed by it, and an access token.
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Names of the files in the credentials directory
+const (
+	CACertFile     = "ca.pem"
+	CAKeyFile      = "ca-key.pem"
+	ServerCertFile = "server.pem"
+	ServerKeyFile  = "server-key.pem"
+	TokenFile      = "token"
+)
+
+const (
+	// ServerName is the name the server certificate is issued for. Clients
+	// verify the server against it, whatever the address they dial
+	ServerName = "srcd-server"
+
+	certValidity = 10 * 365 * 24 * time.Hour
+	tokenBytes   = 32
+	tokenHeader  = "authorization"
+	tokenPrefix  = "Bearer "
+)
+
+// publicServices are the gRPC services that can be called without the access
+// token, so standard probes can use them
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EnsureCredentials creates in dir the credentials files that do not exist
+// yet. If the certificate authority is created, the server certificate is
+// created again too.
+func EnsureCredentials(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrapf(err, "could not create credentials directory %s", dir)
+	}
+
+	newCA := false
+	if !exists(dir, CACertFile) || !exists(dir, CAKeyFile) {
+		if err := createCA(dir); err != nil {
+			return err
+		}
+
+		newCA = true
+	}
+
+	if newCA || !exists(dir, ServerCertFile) || !exists(dir, ServerKeyFile) {
+		if err := createServerCert(dir); err != nil {
+			return err
+		}
+	}
+
+	if !exists(dir, TokenFile) {
+		if err := createToken(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func exists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+func createCA(dir string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "could not generate CA key")
+	}
+
+	tmpl, err := certTemplate("srcd local CA")
+	if err != nil {
+		return err
+	}
+
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return errors.Wrap(err, "could not create CA certificate")
+	}
+
+	return writeKeyPair(dir, CACertFile, CAKeyFile, der, key)
+}
+
+func createServerCert(dir string) error {
+	ca, err := tls.LoadX509KeyPair(
+		filepath.Join(dir, CACertFile),
+		filepath.Join(dir, CAKeyFile),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not load CA")
+	}
+
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	if err != nil {
+		return errors.Wrap(err, "could not parse CA certificate")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "could not generate server key")
+	}
+
+	tmpl, err := certTemplate(ServerName)
+	if err != nil {
+		return err
+	}
+
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	tmpl.DNSNames = []string{ServerName, "localhost"}
+	tmpl.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, ca.PrivateKey)
+	if err != nil {
+		return errors.Wrap(err, "could not create server certificate")
+	}
+
+	return writeKeyPair(dir, ServerCertFile, ServerKeyFile, der, key)
+}
+
+func certTemplate(name string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate serial number")
+	}
+
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name, Organization: []string{"source{d}"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+	}, nil
+}
+
+func writeKeyPair(dir, certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal key")
+	}
+
+	err = writeFile(dir, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	if err != nil {
+		return err
+	}
+
+	return writeFile(dir, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func createToken(dir string) error {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return errors.Wrap(err, "could not generate token")
+	}
+
+	return writeFile(dir, TokenFile, []byte(hex.EncodeToString(b)))
+}
+
+func writeFile(dir, name string, content []byte) error {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		return errors.Wrapf(err, "could not write %s", path)
+	}
+
+	return nil
+}
+
+// ReadToken returns the access token stored in dir
+func ReadToken(dir string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, TokenFile))
+	if err != nil {
+		return "", errors.Wrap(err, "could not read access token")
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// ServerTLSConfig returns the TLS config for the server, using the server
+// certificate stored in dir
+func ServerTLSConfig(dir string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(
+		filepath.Join(dir, ServerCertFile),
+		filepath.Join(dir, ServerKeyFile),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load server certificate")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientTLSConfig returns the TLS config for a client, that only trusts
+// servers with a certificate signed by the CA stored in dir
+func ClientTLSConfig(dir string) (*tls.Config, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, CACertFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read CA certificate")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("invalid CA certificate %s", filepath.Join(dir, CACertFile))
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		ServerName: ServerName,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+type tokenCredentials string
+
+// NewTokenCredentials returns the per-RPC credentials that send the given
+// access token with each call
+func NewTokenCredentials(token string) credentials.PerRPCCredentials {
+	return tokenCredentials(token)
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{tokenHeader: tokenPrefix + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// UnaryServerInterceptor rejects the calls without the given access token,
+// except the ones to the health service
+func UnaryServerInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if err := checkToken(ctx, token); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the streams without the given access
+// token, except the ones to the health service
+func StreamServerInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		if err := checkToken(ss.Context(), token); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func checkToken(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(tokenHeader) {
+		got := strings.TrimPrefix(v, tokenPrefix)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid access token")
+}
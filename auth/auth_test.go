@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestEnsureCredentials(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "srcd-auth")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(EnsureCredentials(dir))
+
+	serverCfg, err := ServerTLSConfig(dir)
+	require.NoError(err)
+	clientCfg, err := ClientTLSConfig(dir)
+	require.NoError(err)
+
+	cert, err := x509.ParseCertificate(serverCfg.Certificates[0].Certificate[0])
+	require.NoError(err)
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName: clientCfg.ServerName,
+		Roots:   clientCfg.RootCAs,
+	})
+	require.NoError(err)
+
+	token, err := ReadToken(dir)
+	require.NoError(err)
+	require.Len(token, 2*tokenBytes)
+
+	// existing credentials are kept
+	require.NoError(EnsureCredentials(dir))
+	token2, err := ReadToken(dir)
+	require.NoError(err)
+	require.Equal(token, token2)
+
+	// a new CA needs a new server certificate
+	require.NoError(os.Remove(filepath.Join(dir, CAKeyFile)))
+	require.NoError(EnsureCredentials(dir))
+	clientCfg, err = ClientTLSConfig(dir)
+	require.NoError(err)
+	serverCfg, err = ServerTLSConfig(dir)
+	require.NoError(err)
+
+	cert, err = x509.ParseCertificate(serverCfg.Certificates[0].Certificate[0])
+	require.NoError(err)
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName: clientCfg.ServerName,
+		Roots:   clientCfg.RootCAs,
+	})
+	require.NoError(err)
+}
+
+func TestCheckToken(t *testing.T) {
+	require := require.New(t)
+
+	creds := NewTokenCredentials("secret")
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(md))
+	require.NoError(checkToken(ctx, "secret"))
+
+	err = checkToken(ctx, "other")
+	require.Equal(codes.Unauthenticated, status.Code(err))
+
+	err = checkToken(context.Background(), "secret")
+	require.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptorPublicServices(t *testing.T) {
+	require := require.New(t)
+
+	interceptor := UnaryServerInterceptor("secret")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	res, err := interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	require.NoError(err)
+	require.Equal("ok", res)
+
+	_, err = interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/Engine/Version"}, handler)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+}
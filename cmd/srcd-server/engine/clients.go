@@ -0,0 +1,229 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sync"
+
+	bblfsh "github.com/bblfsh/go-client/v4"
+	"github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	"github.com/src-d/engine/components"
+	"google.golang.org/grpc"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// connPool keeps long-lived connections to the components, created lazily
+// the first time they are needed. The connections of a container are retired
+// when it is started again or removed, and the next call creates new ones.
+// A retired connection is closed once it is released by all its users.
+// The connections are dialed without holding the lock of the pool, the
+// concurrent callers asking for the same key wait for the first dial.
+type connPool struct {
+	mu    sync.Mutex
+	conns map[string]*poolConn
+}
+
+type poolConn struct {
+	container string
+	// ready is closed once the dial of the connection is done, conn and err
+	// must not be read before
+	ready chan struct{}
+	conn  io.Closer
+	err   error
+	// refs is the number of users of the connection, including the ones
+	// waiting for it to be dialed
+	refs int
+	// retired is true once the connection is not returned by the pool anymore
+	retired bool
+}
+
+func newConnPool() *connPool {
+	return &connPool{conns: make(map[string]*poolConn)}
+}
+
+// get returns the connection with the given key to the container, calling
+// dial to create it if there is none. The returned function must be called
+// when the connection is not used anymore.
+func (p *connPool) get(
+	ctx context.Context,
+	key, container string,
+	dial func(context.Context) (io.Closer, error),
+) (io.Closer, func(), error) {
+	p.mu.Lock()
+	c, ok := p.conns[key]
+	if !ok {
+		c = &poolConn{container: container, ready: make(chan struct{})}
+		p.conns[key] = c
+	}
+	c.refs++
+	p.mu.Unlock()
+
+	var once sync.Once
+	release := func() { once.Do(func() { p.release(c) }) }
+
+	if !ok {
+		conn, err := dial(ctx)
+
+		p.mu.Lock()
+		c.conn, c.err = conn, err
+		if err != nil && p.conns[key] == c {
+			delete(p.conns, key)
+		}
+		p.mu.Unlock()
+
+		close(c.ready)
+	}
+
+	select {
+	case <-c.ready:
+	case <-ctx.Done():
+		release()
+		return nil, nil, ctx.Err()
+	}
+
+	if c.err != nil {
+		release()
+		return nil, nil, c.err
+	}
+
+	return c.conn, release, nil
+}
+
+func (p *connPool) release(c *poolConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c.refs--
+	if c.retired && c.refs == 0 {
+		closeConn(c)
+	}
+}
+
+// retire removes the connections to the container from the pool, closing the
+// ones that are not in use
+func (p *connPool) retire(container string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, c := range p.conns {
+		if c.container != container {
+			continue
+		}
+
+		log.Debugf("container %s changed, retiring connection %s", container, key)
+		delete(p.conns, key)
+		c.retired = true
+		if c.refs == 0 {
+			closeConn(c)
+		}
+	}
+}
+
+// closeConn closes the connection, if it was dialed successfully. It must be
+// called once there are no users of c, so the dial is already done.
+func closeConn(c *poolConn) {
+	if c.conn == nil {
+		return
+	}
+
+	if err := c.conn.Close(); err != nil {
+		log.Errorf(err, "could not close connection to %s", c.container)
+	}
+}
+
+// Close closes all the connections of the pool, even if they are in use.
+func (p *connPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for key, c := range p.conns {
+		// the connections being dialed are closed by their last user
+		if c.conn == nil {
+			c.retired = true
+			delete(p.conns, key)
+			continue
+		}
+
+		if err := c.conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(p.conns, key)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("could not close %d connections: %v", len(errs), errs)
+	}
+
+	return nil
+}
+
+// bblfshClient returns the client for the bblfshd parsing endpoint, and the
+// function to call once it is not used anymore. bblfshd must be running.
+func (s *Server) bblfshClient(ctx context.Context) (*bblfsh.Client, func(), error) {
+	conn, release, err := s.conns.get(ctx, "bblfshd-parse", bblfshd.Name, func(ctx context.Context) (io.Closer, error) {
+		addr := fmt.Sprintf("%s:%d", bblfshd.Name, components.BblfshParsePort)
+		log.Infof("connecting to bblfsh parsing on %s", addr)
+		client, err := bblfsh.NewClientContext(ctx, addr)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not connect to bblfsh")
+		}
+
+		return client, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn.(*bblfsh.Client), release, nil
+}
+
+// bblfshControlConn returns the connection to the bblfshd management
+// endpoint, and the function to call once it is not used anymore. bblfshd
+// must be running.
+func (s *Server) bblfshControlConn(ctx context.Context) (*grpc.ClientConn, func(), error) {
+	conn, release, err := s.conns.get(ctx, "bblfshd-control", bblfshd.Name, func(ctx context.Context) (io.Closer, error) {
+		addr := fmt.Sprintf("%s:%d", bblfshd.Name, components.BblfshControlPort)
+		log.Infof("connecting to bblfsh management on %s", addr)
+		conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not connect to bblfsh drivers")
+		}
+
+		return conn, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn.(*grpc.ClientConn), release, nil
+}
+
+// gitbaseDB returns the database handle for gitbase, and the function to call
+// once it is not used anymore. gitbase must be running.
+func (s *Server) gitbaseDB(ctx context.Context) (*sql.DB, func(), error) {
+	conn, release, err := s.conns.get(ctx, "gitbase", gitbase.Name, func(context.Context) (io.Closer, error) {
+		cfg := mysql.Config{
+			User:                 "root",
+			Net:                  "tcp",
+			Addr:                 gitbase.Name,
+			AllowNativePasswords: true,
+			MaxAllowedPacket:     32 << 20, // 32 MiB
+		}
+		log.Infof("connecting to mysql %q", cfg.FormatDSN())
+		db, err := sql.Open("mysql", cfg.FormatDSN())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not connect to gitbase")
+		}
+
+		return db, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn.(*sql.DB), release, nil
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/require"
+)
+
+type mockConn struct {
+	closed bool
+}
+
+func (c *mockConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestConnPool(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	p := newConnPool()
+
+	var dialed int
+	dial := func(context.Context) (io.Closer, error) {
+		dialed++
+		return &mockConn{}, nil
+	}
+
+	c1, release1, err := p.get(ctx, "key", "container", dial)
+	require.NoError(err)
+	c2, release2, err := p.get(ctx, "key", "container", dial)
+	require.NoError(err)
+	require.True(c1 == c2)
+	require.Equal(1, dialed)
+
+	other, releaseOther, err := p.get(ctx, "other", "container", dial)
+	require.NoError(err)
+	require.False(other == c1)
+	require.Equal(2, dialed)
+	releaseOther()
+
+	// the container was recreated, the connections in use are not closed
+	p.retire("container")
+	require.False(c1.(*mockConn).closed)
+	require.True(other.(*mockConn).closed)
+
+	c3, release3, err := p.get(ctx, "key", "container", dial)
+	require.NoError(err)
+	require.False(c3 == c1)
+	require.Equal(3, dialed)
+	defer release3()
+
+	release1()
+	// releasing twice has no effect
+	release1()
+	require.False(c1.(*mockConn).closed)
+	release2()
+	require.True(c1.(*mockConn).closed)
+
+	require.NoError(p.Close())
+	require.True(c3.(*mockConn).closed)
+}
+
+func TestConnPoolDockerEvents(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	s := &Server{conns: newConnPool(), events: newEventBus(eventsHistorySize)}
+	dial := func(context.Context) (io.Closer, error) {
+		return &mockConn{}, nil
+	}
+
+	c, release, err := s.conns.get(ctx, "key", "container", dial)
+	require.NoError(err)
+	release()
+
+	s.HandleDockerEvent(docker.Event{Type: docker.EventCreated, Container: "container"})
+	require.False(c.(*mockConn).closed)
+
+	s.HandleDockerEvent(docker.Event{Type: docker.EventStarted, Container: "other"})
+	require.False(c.(*mockConn).closed)
+
+	s.HandleDockerEvent(docker.Event{Type: docker.EventStarted, Container: "container"})
+	require.True(c.(*mockConn).closed)
+
+	c, release, err = s.conns.get(ctx, "key", "container", dial)
+	require.NoError(err)
+	release()
+
+	s.HandleDockerEvent(docker.Event{Type: docker.EventRemoved, Container: "container"})
+	require.True(c.(*mockConn).closed)
+}
+
+func TestConnPoolConcurrentDial(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	p := newConnPool()
+
+	dialing := make(chan struct{})
+	unblock := make(chan struct{})
+	slowDial := func(context.Context) (io.Closer, error) {
+		close(dialing)
+		<-unblock
+		return &mockConn{}, nil
+	}
+
+	type result struct {
+		conn io.Closer
+		err  error
+	}
+	slow := make(chan result)
+	go func() {
+		c, release, err := p.get(ctx, "slow", "container", slowDial)
+		if err == nil {
+			release()
+		}
+		slow <- result{c, err}
+	}()
+	<-dialing
+
+	// the pool is not locked while the other key is being dialed
+	c, release, err := p.get(ctx, "other", "container", func(context.Context) (io.Closer, error) {
+		return &mockConn{}, nil
+	})
+	require.NoError(err)
+	release()
+	require.NotNil(c)
+
+	// the callers waiting for the dial give up when their context is done
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, _, err = p.get(waitCtx, "slow", "container", slowDial)
+	require.Equal(context.DeadlineExceeded, err)
+
+	waiter := make(chan result)
+	go func() {
+		c, release, err := p.get(ctx, "slow", "container", slowDial)
+		if err == nil {
+			release()
+		}
+		waiter <- result{c, err}
+	}()
+
+	close(unblock)
+	r1, r2 := <-slow, <-waiter
+	require.NoError(r1.err)
+	require.NoError(r2.err)
+	require.True(r1.conn == r2.conn)
+}
+
+func TestConnPoolDialError(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	p := newConnPool()
+
+	_, _, err := p.get(ctx, "key", "container", func(context.Context) (io.Closer, error) {
+		return nil, errors.New("connection refused")
+	})
+	require.EqualError(err, "connection refused")
+
+	// the failed dial is not kept
+	c, release, err := p.get(ctx, "key", "container", func(context.Context) (io.Closer, error) {
+		return &mockConn{}, nil
+	})
+	require.NoError(err)
+	require.NotNil(c)
+	release()
+}
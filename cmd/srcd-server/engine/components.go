@@ -3,12 +3,14 @@ package engine
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/src-d/engine/api"
 	"github.com/src-d/engine/components"
 	"github.com/src-d/engine/docker"
+	"gopkg.in/src-d/go-log.v1"
 )
 
 const (
@@ -17,37 +19,59 @@ const (
 
 // Component to be run.
 type Component struct {
-	Name         string
-	Start        docker.StartFunc
+	Name string
+	// Image and Version are the docker image of the component. If they are
+	// set, the image is pulled without waiting for the dependencies
+	Image   string
+	Version string
+	// Start creates and starts the component container. A dependency without
+	// Start refers to the component with the same name defined elsewhere
+	Start docker.StartFunc
+	// Ready is the readiness probe of the component. If it is nil, the
+	// component is ready as soon as its container is running
+	Ready docker.ReadyFunc
+	// ReadyTimeout is the time the component has to become ready, by default
+	// api.DefaultReadyTimeout
+	ReadyTimeout time.Duration
 	Dependencies []Component
 }
 
-// Run the given components if they're not already running. It will recursively
-// run all the component dependencies.
-func Run(ctx context.Context, cs ...Component) error {
-	return run(ctx, cs, make(map[string]struct{}))
+// ComponentError is returned by Run when a component can't be started
+type ComponentError struct {
+	Name string
+	Err  error
 }
 
-func run(ctx context.Context, cs []Component, seen map[string]struct{}) error {
-	for _, c := range cs {
-		if len(c.Dependencies) > 0 {
-			if err := run(ctx, c.Dependencies, seen); err != nil {
-				return err
-			}
-		}
+func (e *ComponentError) Error() string {
+	return e.Err.Error()
+}
 
-		if _, ok := seen[c.Name]; ok {
-			continue
-		}
+// Run the given components if they're not already running. It will run all
+// the component dependencies too, each component as soon as its dependencies
+// are ready, and independent components concurrently. If any component fails
+// the containers started by the call are removed, except the ones used by a
+// concurrent call.
+func Run(ctx context.Context, cs ...Component) error {
+	sorted, err := sortComponents(cs)
+	if err != nil {
+		return err
+	}
 
-		seen[c.Name] = struct{}{}
-		_, err := docker.InfoOrStart(ctx, c.Name, c.Start)
-		if err != nil {
-			return err
-		}
+	return runSorted(ctx, sorted)
+}
+
+// run calls Run, publishing an event if a component fails
+func (s *Server) run(ctx context.Context, cs ...Component) error {
+	err := Run(ctx, cs...)
+	if cerr, ok := err.(*ComponentError); ok {
+		s.events.publish(&api.Event{
+			Type:      api.Event_FAILED,
+			Component: cerr.Name,
+			Error:     cerr.Err.Error(),
+		})
 	}
 
-	return nil
+	return err
 }
 
 func (s *Server) StartComponent(
@@ -55,7 +79,10 @@ func (s *Server) StartComponent(
 	r *api.StartComponentRequest,
 ) (*api.StartComponentResponse, error) {
 	port, err := s.startComponentAtPort(ctx, r.Name, int(r.Port))
-	return &api.StartComponentResponse{Port: int32(port)}, err
+	return &api.StartComponentResponse{
+		Port:   int32(port),
+		HostIp: s.getHostIP(r.Name),
+	}, err
 }
 
 func (s *Server) StopComponent(
@@ -71,57 +98,83 @@ func (s *Server) startComponent(ctx context.Context, name string) error {
 }
 
 // startComponentAtPort starts the container with the given public port binding.
-// If port is 0, the one set in the initial --config will be used.
+// If port is 0, the one set in the initial --config will be used, or one
+// chosen by docker for the components of a workspace other than the default.
 // If port is -1, the public port will be the same as the private one.
 func (s *Server) startComponentAtPort(
 	ctx context.Context, name string, port int,
 ) (int, error) {
-
-	var err error
 	publicPort := s.getPublicPort(name, port)
 
-	switch name {
-	case gitbaseWeb.Name:
-		gbComp, err := s.gitbaseComponent(0)
+	var cs []Component
+	if _, ok := s.customConfig(name); ok {
+		var err error
+		cs, err = s.customComponents(name, port)
 		if err != nil {
-			break
+			return 0, errors.Wrapf(err, "can't start component %s", name)
 		}
-
-		return publicPort, Run(ctx, Component{
-			Name:         gitbaseWeb.Name,
-			Start:        createGitbaseWeb(docker.WithPort(publicPort, components.GitbaseWebPort)),
-			Dependencies: []Component{*gbComp},
-		})
-	case bblfshWeb.Name:
-		bbfComp, err := s.bblfshComponent(0)
+	} else {
+		c, err := s.builtinComponent(name, port)
 		if err != nil {
-			break
+			return 0, err
 		}
 
-		return publicPort, Run(ctx, Component{
-			Name:         bblfshWeb.Name,
-			Start:        createBblfshWeb(docker.WithPort(publicPort, components.BblfshWebPort)),
-			Dependencies: []Component{*bbfComp},
-		})
-	case bblfshd.Name:
-		bbfComp, err := s.bblfshComponent(port)
-		if err != nil {
-			break
-		}
+		cs = []Component{*c}
+	}
 
-		return publicPort, Run(ctx, *bbfComp)
-	case gitbase.Name:
-		gbComp, err := s.gitbaseComponent(port)
-		if err != nil {
-			break
+	if err := s.run(ctx, cs...); err != nil {
+		return 0, err
+	}
+
+	// the port was chosen by docker
+	if privatePort := s.getPublicPort(name, -1); publicPort == 0 && privatePort != 0 {
+		return publishedPort(name, privatePort)
+	}
+
+	return publicPort, nil
+}
+
+// publishedPort returns the public port bound to a private port of the
+// running container with the given name
+func publishedPort(name string, privatePort int) (int, error) {
+	info, err := docker.Info(name)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can't get container %s", name)
+	}
+
+	for _, p := range info.Ports {
+		if int(p.PrivatePort) == privatePort && p.PublicPort != 0 {
+			return int(p.PublicPort), nil
 		}
+	}
+
+	return 0, fmt.Errorf("container %s has no public port for %d", name, privatePort)
+}
+
+// builtinComponent returns the built-in component with the given container
+// name, with its dependencies
+func (s *Server) builtinComponent(name string, port int) (*Component, error) {
+	var c *Component
+	var err error
 
-		return publicPort, Run(ctx, *gbComp)
+	switch name {
+	case gitbaseWeb.Name:
+		c, err = s.gitbaseWebComponent(port)
+	case bblfshWeb.Name:
+		c, err = s.bblfshWebComponent(port)
+	case bblfshd.Name:
+		c, err = s.bblfshComponent(port)
+	case gitbase.Name:
+		c, err = s.gitbaseComponent(port)
 	default:
-		return 0, fmt.Errorf("can't start unknown component %s", name)
+		return nil, fmt.Errorf("can't start unknown component %s", name)
 	}
 
-	return 0, errors.Wrapf(err, "can't start component %s", name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't start component %s", name)
+	}
+
+	return c, nil
 }
 
 func (s *Server) getPublicPort(name string, requestedPort int) int {
@@ -142,8 +195,22 @@ func (s *Server) getPublicPort(name string, requestedPort int) int {
 		privatePort = components.GitbasePort
 	}
 
+	if c, ok := s.customConfig(name); ok && len(c.Ports) > 0 {
+		defaultPort = c.Ports[0].Port
+		privatePort = c.Ports[0].PrivatePort
+	}
+
+	// the ports of the config are used by the default workspace, the ones of
+	// the components of other workspaces are chosen by docker
+	workspace := components.Workspace() != components.DefaultWorkspace
+	shared := name == bblfshd.Name || name == bblfshWeb.Name
+
 	switch requestedPort {
 	case 0:
+		if workspace && !shared {
+			return 0
+		}
+
 		return defaultPort
 	case -1:
 		return privatePort
@@ -152,11 +219,79 @@ func (s *Server) getPublicPort(name string, requestedPort int) int {
 	}
 }
 
+// getHostIP returns the host address the public port of the component must be
+// bound to
+func (s *Server) getHostIP(name string) string {
+	switch name {
+	case gitbaseWeb.Name:
+		return s.config.Components.GitbaseWeb.HostIP
+	case bblfshWeb.Name:
+		return s.config.Components.BblfshWeb.HostIP
+	case bblfshd.Name:
+		return s.config.Components.Bblfshd.HostIP
+	case gitbase.Name:
+		return s.config.Components.Gitbase.HostIP
+	}
+
+	if c, ok := s.customConfig(name); ok && len(c.Ports) > 0 {
+		return c.Ports[0].HostIP
+	}
+
+	return s.config.HostIP
+}
+
+func (s *Server) gitbaseWebComponent(port int) (*Component, error) {
+	port = s.getPublicPort(gitbaseWeb.Name, port)
+
+	gbComp, err := s.gitbaseComponent(0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't create %s component", gitbase.Name)
+	}
+
+	conf := s.config.Components.GitbaseWeb
+	opts := append([]docker.ConfigOption{
+		docker.WithPort(s.getHostIP(gitbaseWeb.Name), port, components.GitbaseWebPort),
+	}, conf.Options()...)
+
+	return &Component{
+		Name:         gitbaseWeb.Name,
+		Image:        gitbaseWeb.Image,
+		Version:      gitbaseWeb.Version,
+		Start:        createGitbaseWeb(conf.SelectLimit, opts...),
+		Ready:        docker.TCPReady(fmt.Sprintf("%s:%d", gitbaseWeb.Name, components.GitbaseWebPort)),
+		ReadyTimeout: s.config.ReadyTimeout,
+		Dependencies: []Component{*gbComp},
+	}, nil
+}
+
+func (s *Server) bblfshWebComponent(port int) (*Component, error) {
+	port = s.getPublicPort(bblfshWeb.Name, port)
+
+	bbfComp, err := s.bblfshComponent(0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't create %s component", bblfshd.Name)
+	}
+
+	opts := append([]docker.ConfigOption{
+		docker.WithPort(s.getHostIP(bblfshWeb.Name), port, components.BblfshWebPort),
+	}, s.config.Components.BblfshWeb.Options()...)
+
+	return &Component{
+		Name:         bblfshWeb.Name,
+		Image:        bblfshWeb.Image,
+		Version:      bblfshWeb.Version,
+		Start:        createBblfshWeb(opts...),
+		Ready:        docker.TCPReady(fmt.Sprintf("%s:%d", bblfshWeb.Name, components.BblfshWebPort)),
+		ReadyTimeout: s.config.ReadyTimeout,
+		Dependencies: []Component{*bbfComp},
+	}, nil
+}
+
 func (s *Server) gitbaseComponent(port int) (*Component, error) {
 	port = s.getPublicPort(gitbase.Name, port)
 
 	indexVolumeName := fmt.Sprintf("srcd-cli-gitbase-%s", s.workdirHash)
-	if err := docker.CreateVolume(context.TODO(), indexVolumeName); err != nil {
+	if err := docker.CreateVolume(context.TODO(), indexVolumeName, gitbase.Name); err != nil {
 		return nil, errors.Wrapf(err, "can't create volume for gitbase index")
 	}
 
@@ -170,24 +305,101 @@ func (s *Server) gitbaseComponent(port int) (*Component, error) {
 		return nil, errors.Wrapf(err, "can't create %s component", bblfshd.Name)
 	}
 
+	opts := append([]docker.ConfigOption{
+		docker.WithROSharedDirectory(workdirHostPath, gitbaseMountPath, s.hostOS),
+		docker.WithVolume(indexVolumeName, gitbaseIndexMountPath, s.hostOS),
+		docker.WithPort(s.getHostIP(gitbase.Name), port, components.GitbasePort),
+	}, s.config.Components.Gitbase.Options()...)
+
 	return &Component{
-		Name: gitbase.Name,
-		Start: s.createGitbase(
-			docker.WithROSharedDirectory(workdirHostPath, gitbaseMountPath, s.hostOS),
-			docker.WithVolume(indexVolumeName, gitbaseIndexMountPath, s.hostOS),
-			docker.WithPort(port, components.GitbasePort),
-		),
+		Name:         gitbase.Name,
+		Image:        gitbase.Image,
+		Version:      gitbase.Version,
+		Start:        s.createGitbase(opts...),
+		Ready:        s.checkGitbase,
+		ReadyTimeout: s.config.ReadyTimeout,
 		Dependencies: []Component{*bblfshComponent},
 	}, nil
 }
 
+// bblfshdVolumeName returns the name of the volume that stores the drivers of
+// a bblfshd version
+func bblfshdVolumeName(version string) string {
+	return fmt.Sprintf("%s-%s", bblfshd.Name, version)
+}
+
+// removeOldBblfshdVolumes removes the drivers volumes of the bblfshd versions
+// other than the one of the current volume. The drivers installed at runtime
+// in them are lost, they are reinstalled by the next sync only if they are
+// declared in the config.
+func removeOldBblfshdVolumes(ctx context.Context, current string) error {
+	vols, err := docker.ListOwnedVolumes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range vols {
+		if v.Name == current || !strings.HasPrefix(v.Name, bblfshdVolumeName("")) {
+			continue
+		}
+
+		if c, ok := v.Labels[docker.LabelComponent]; ok && c != bblfshd.Name {
+			continue
+		}
+
+		log.Warningf("removing volume %s of a previous bblfshd version, "+
+			"the drivers installed in it that are not declared in the config "+
+			"must be installed again", v.Name)
+		if err := docker.RemoveVolume(ctx, v.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Server) bblfshComponent(port int) (*Component, error) {
 	port = s.getPublicPort(bblfshd.Name, port)
 
+	// The volume is populated with the drivers bundled in the image the first
+	// time it is used. The image version is part of the name so an update of
+	// bblfshd also updates the bundled drivers.
+	storageVolumeName := bblfshdVolumeName(bblfshd.Version)
+	if err := docker.CreateVolume(context.TODO(), storageVolumeName, bblfshd.Name); err != nil {
+		return nil, errors.Wrapf(err, "can't create volume for bblfshd storage")
+	}
+
+	opts := append([]docker.ConfigOption{
+		docker.WithVolume(storageVolumeName, bblfshdStorageMountPath, s.hostOS),
+		docker.WithPort(s.getHostIP(bblfshd.Name), port, components.BblfshParsePort),
+	}, s.config.Components.Bblfshd.Options()...)
+
+	start := createBbblfshd(opts...)
+
 	return &Component{
-		Name: bblfshd.Name,
-		Start: createBbblfshd(
-			docker.WithPort(port, components.BblfshParsePort),
-		),
+		Name:    bblfshd.Name,
+		Image:   bblfshd.Image,
+		Version: bblfshd.Version,
+		Start: func(ctx context.Context) error {
+			if err := start(ctx); err != nil {
+				return err
+			}
+
+			// the volumes of other versions are not used by any container once
+			// the new one is started
+			if err := removeOldBblfshdVolumes(ctx, storageVolumeName); err != nil {
+				log.Errorf(err, "could not remove the volumes of previous bblfshd versions")
+			}
+
+			// a failure here should not prevent the use of bblfshd, the drift
+			// is reported by ListDrivers
+			if err := s.syncDrivers(ctx); err != nil {
+				log.Errorf(err, "could not sync bblfsh drivers with the config")
+			}
+
+			return nil
+		},
+		Ready:        s.checkBblfshd,
+		ReadyTimeout: s.config.ReadyTimeout,
 	}, nil
 }
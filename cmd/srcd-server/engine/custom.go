@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// customConfig returns the config of the component declared in the config
+// file with the given container name
+func (s *Server) customConfig(name string) (api.CustomComponent, bool) {
+	short := components.ShortName(name)
+	if components.ContainerName(short) != name {
+		return api.CustomComponent{}, false
+	}
+
+	c, ok := s.config.Components.Custom[short]
+	return c, ok
+}
+
+// customComponents returns the custom component with the given container
+// name, followed by the custom components it depends on, directly or not.
+// The custom dependencies are only referenced by name, so Run can detect
+// cycles between them.
+func (s *Server) customComponents(name string, port int) ([]Component, error) {
+	var result []Component
+	seen := make(map[string]bool)
+
+	var add func(name string, port int) error
+	add = func(name string, port int) error {
+		if seen[name] {
+			return nil
+		}
+
+		seen[name] = true
+
+		conf, ok := s.customConfig(name)
+		if !ok {
+			return fmt.Errorf("unknown component %s", name)
+		}
+
+		c := Component{
+			Name:         name,
+			Image:        conf.Image,
+			Version:      conf.Version,
+			Start:        s.createCustom(name, conf, port),
+			ReadyTimeout: s.config.ReadyTimeout,
+		}
+
+		if len(conf.Ports) > 0 {
+			c.Ready = docker.TCPReady(fmt.Sprintf("%s:%d", name, conf.Ports[0].PrivatePort))
+		}
+
+		for _, dep := range conf.Dependencies {
+			depName := components.ContainerName(dep)
+			if _, ok := s.customConfig(depName); !ok {
+				b, err := s.builtinComponent(depName, 0)
+				if err != nil {
+					return err
+				}
+
+				c.Dependencies = append(c.Dependencies, *b)
+				continue
+			}
+
+			c.Dependencies = append(c.Dependencies, Component{Name: depName})
+			if err := add(depName, 0); err != nil {
+				return err
+			}
+		}
+
+		result = append(result, c)
+		return nil
+	}
+
+	if err := add(name, port); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *Server) createCustom(name string, conf api.CustomComponent, port int) docker.StartFunc {
+	return func(ctx context.Context) error {
+		if err := docker.EnsureInstalled(conf.Image, conf.Version); err != nil {
+			return err
+		}
+
+		log.Infof("starting %s", name)
+
+		ctx, cancel := context.WithTimeout(context.Background(), startComponentTimeout)
+		defer cancel()
+
+		config := &container.Config{
+			Image: fmt.Sprintf("%s:%s", conf.Image, conf.Version),
+			Cmd:   conf.Command,
+		}
+		host := &container.HostConfig{}
+
+		opts := []docker.ConfigOption{
+			docker.WithEnvMap(conf.Env),
+			docker.WithLimits(conf.Limits()),
+		}
+
+		for i, p := range conf.Ports {
+			// the public port of the first one can be set in the request
+			public := p.Port
+			if i == 0 {
+				public = s.getPublicPort(name, port)
+			}
+
+			opts = append(opts, docker.WithPort(p.HostIP, public, p.PrivatePort))
+		}
+
+		for _, m := range conf.Mounts {
+			opt, err := s.customMount(ctx, name, m)
+			if err != nil {
+				return err
+			}
+
+			opts = append(opts, opt)
+		}
+
+		docker.ApplyOptions(config, host, opts...)
+
+		return docker.Start(ctx, config, host, name)
+	}
+}
+
+// customMount returns the option to mount a host directory, if the source is
+// an absolute path, or a docker volume, creating it for the named component
+// if it does not exist
+func (s *Server) customMount(ctx context.Context, name string, m api.Mount) (docker.ConfigOption, error) {
+	if !isAbsHostPath(m.Source) {
+		if err := docker.CreateVolume(ctx, m.Source, name); err != nil {
+			return nil, errors.Wrapf(err, "can't create volume %s", m.Source)
+		}
+
+		if m.ReadOnly {
+			return docker.WithROVolume(m.Source, m.Target, s.hostOS), nil
+		}
+
+		return docker.WithVolume(m.Source, m.Target, s.hostOS), nil
+	}
+
+	hostPath, err := docker.HostPath(m.Source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't process host path %s", m.Source)
+	}
+
+	if m.ReadOnly {
+		return docker.WithROSharedDirectory(hostPath, m.Target, s.hostOS), nil
+	}
+
+	return docker.WithSharedDirectory(hostPath, m.Target, s.hostOS), nil
+}
+
+// isAbsHostPath returns whether path is absolute in the host, which can be
+// running Windows
+func isAbsHostPath(path string) bool {
+	return strings.HasPrefix(path, "/") ||
+		(len(path) > 2 && path[1] == ':' && (path[2] == '\\' || path[2] == '/'))
+}
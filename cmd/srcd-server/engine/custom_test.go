@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/src-d/engine/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomComponents(t *testing.T) {
+	require := require.New(t)
+
+	var config api.Config
+	config.Components.Custom = map[string]api.CustomComponent{
+		"analysis": {
+			Image:        "myorg/analysis",
+			Ports:        []api.CustomPort{{Port: 9000, PrivatePort: 8000}},
+			Dependencies: []string{"indexer"},
+		},
+		"indexer": {Image: "myorg/indexer", Dependencies: []string{"cache"}},
+		"cache":   {Image: "myorg/cache", Dependencies: []string{"analysis"}},
+	}
+	config.SetDefaults()
+
+	s := NewServer("", "/tmp", "linux", config)
+	require.Equal(9000, s.getPublicPort("srcd-cli-analysis", 0))
+	require.Equal(8000, s.getPublicPort("srcd-cli-analysis", -1))
+	require.Equal(api.DefaultHostIP, s.getHostIP("srcd-cli-analysis"))
+
+	cs, err := s.customComponents("srcd-cli-analysis", 0)
+	require.NoError(err)
+	require.Equal([]string{"srcd-cli-cache", "srcd-cli-indexer", "srcd-cli-analysis"}, componentNames(cs))
+	require.NotNil(cs[2].Ready)
+	require.Nil(cs[0].Ready)
+
+	_, err = sortComponents(cs[2:])
+	require.EqualError(err, "unknown component srcd-cli-indexer")
+
+	_, err = sortComponents([]Component{cs[2], cs[0], cs[1]})
+	require.EqualError(err, "dependency cycle between components: "+
+		"srcd-cli-analysis -> srcd-cli-indexer -> srcd-cli-cache -> srcd-cli-analysis")
+}
+
+func TestIsAbsHostPath(t *testing.T) {
+	require := require.New(t)
+
+	require.True(isAbsHostPath("/home/user/data"))
+	require.True(isAbsHostPath(`C:\Users\data`))
+	require.True(isAbsHostPath("C:/Users/data"))
+	require.False(isAbsHostPath("analysis-cache"))
+}
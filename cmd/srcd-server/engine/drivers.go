@@ -3,50 +3,366 @@ package engine
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	drivers "github.com/bblfsh/bblfshd/daemon/protocol"
 	"github.com/pkg/errors"
 	"github.com/src-d/engine/api"
-	"github.com/src-d/engine/components"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/src-d/go-log.v1"
 )
 
-var ErrDriverAlreadyInstalled = errors.New("driver already installed")
+// ErrDriverAlreadyInstalled is returned by InstallDriver when the driver is
+// installed and the update was not requested
+var ErrDriverAlreadyInstalled = status.Error(codes.AlreadyExists, "driver already installed")
 
-func (s *Server) bblfshDriverClient(ctx context.Context) (drivers.ProtocolServiceClient, error) {
+// bblfshDriverClient starts bblfshd and returns the client of its management
+// endpoint, and the function to call once it is not used anymore
+func (s *Server) bblfshDriverClient(ctx context.Context) (drivers.ProtocolServiceClient, func(), error) {
 	if err := s.startComponent(ctx, bblfshd.Name); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	addr := fmt.Sprintf("%s:%d", bblfshd.Name, components.BblfshControlPort)
-	log.Infof("connecting to bblfsh management on %s", addr)
-	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	return s.dialBblfshDriverClient(ctx)
+}
+
+func (s *Server) dialBblfshDriverClient(ctx context.Context) (drivers.ProtocolServiceClient, func(), error) {
+	conn, release, err := s.bblfshControlConn(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not connect to bblfsh drivers")
+		return nil, nil, err
 	}
 
-	return drivers.NewProtocolServiceClient(conn), nil
+	return drivers.NewProtocolServiceClient(conn), release, nil
 }
 
 func (s *Server) ListDrivers(ctx context.Context, req *api.ListDriversRequest) (*api.ListDriversResponse, error) {
-	client, err := s.bblfshDriverClient(ctx)
+	client, release, err := s.bblfshDriverClient(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	res, err := client.DriverStates(ctx, &drivers.DriverStatesRequest{})
 	if err != nil {
 		return nil, errors.Wrap(err, "could not list drivers from bblfsh")
 	}
 
-	var list api.ListDriversResponse
-	for _, state := range res.State {
-		list.Drivers = append(list.Drivers, &api.ListDriversResponse_DriverInfo{
+	list := compareDrivers(s.config.Drivers, res.State)
+	if req.Status {
+		if err := s.addDriversStatus(ctx, client, list, res.State); err != nil {
+			return nil, err
+		}
+	}
+
+	return &api.ListDriversResponse{Drivers: list}, nil
+}
+
+// addDriversStatus fills the pool and instances state of the given drivers
+func (s *Server) addDriversStatus(
+	ctx context.Context,
+	client drivers.ProtocolServiceClient,
+	list []*api.ListDriversResponse_DriverInfo,
+	states []*drivers.DriverImageState,
+) error {
+	pools, err := client.DriverPoolStates(ctx, &drivers.DriverPoolStatesRequest{})
+	if err != nil {
+		return errors.Wrap(err, "could not get driver pools state from bblfsh")
+	}
+
+	instances, err := client.DriverInstanceStates(ctx, &drivers.DriverInstanceStatesRequest{})
+	if err != nil {
+		return errors.Wrap(err, "could not get driver instances state from bblfsh")
+	}
+
+	// instances only know their image, use it to find their language
+	imageLang := make(map[string]string, len(states))
+	for _, state := range states {
+		imageLang[imageName(state.Reference)] = state.Language
+	}
+
+	byLang := make(map[string]*api.ListDriversResponse_DriverInfo, len(list))
+	for _, info := range list {
+		byLang[info.Lang] = info
+		info.LastError = s.parseError(info.Lang)
+
+		if pool, ok := pools.State[info.Lang]; ok {
+			info.Pool = &api.ListDriversResponse_PoolState{
+				Wanted:  int32(pool.Wanted),
+				Running: int32(pool.Running),
+				Waiting: int32(pool.Waiting),
+				Success: int32(pool.Success),
+				Errors:  int32(pool.Errors),
+				Exited:  int32(pool.Exited),
+			}
+		}
+	}
+
+	for _, instance := range instances.State {
+		info, ok := byLang[imageLang[imageName(instance.Image)]]
+		if !ok {
+			continue
+		}
+
+		processes := make([]int32, len(instance.Processes))
+		for i, p := range instance.Processes {
+			processes[i] = int32(p)
+		}
+
+		info.Instances = append(info.Instances, &api.ListDriversResponse_InstanceState{
+			Id:        instance.ID,
+			Image:     instance.Image,
+			Status:    instance.Status.String(),
+			Created:   instance.Created.Unix(),
+			Processes: processes,
+		})
+	}
+
+	return nil
+}
+
+// imageName removes the transport from an image reference, e.g. for
+// docker://bblfsh/go-driver:latest it returns bblfsh/go-driver:latest
+func imageName(ref string) string {
+	if i := strings.Index(ref, "://"); i >= 0 {
+		return ref[i+len("://"):]
+	}
+
+	return ref
+}
+
+func (s *Server) InstallDriver(ctx context.Context, req *api.InstallDriverRequest) (*api.InstallDriverResponse, error) {
+	client, release, err := s.bblfshDriverClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	lang := strings.ToLower(req.Lang)
+	image := driverImageReference(lang, req.ImageReference)
+	log.Infof("installing driver for %s from %s", lang, image)
+
+	res, err := client.InstallDriver(ctx, &drivers.InstallDriverRequest{
+		Language:       lang,
+		ImageReference: image,
+		Update:         req.Update,
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return nil, ErrDriverAlreadyInstalled
+	}
+	if err == nil {
+		err = responseErr(res)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not install driver for %s", lang)
+	}
+
+	return &api.InstallDriverResponse{}, nil
+}
+
+func (s *Server) RemoveDriver(ctx context.Context, req *api.RemoveDriverRequest) (*api.RemoveDriverResponse, error) {
+	client, release, err := s.bblfshDriverClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	lang := strings.ToLower(req.Lang)
+	log.Infof("removing driver for %s", lang)
+
+	res, err := client.RemoveDriver(ctx, &drivers.RemoveDriverRequest{Language: lang})
+	if err == nil {
+		err = responseErr(res)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not remove driver for %s", lang)
+	}
+
+	return &api.RemoveDriverResponse{}, nil
+}
+
+// driverImageReference returns the image reference in the format expected by
+// bblfshd, transport://name:tag. If image is empty the official driver image
+// for the language is used.
+func driverImageReference(lang, image string) string {
+	if image == "" {
+		image = fmt.Sprintf("bblfsh/%s-driver:latest", lang)
+	}
+
+	if !strings.Contains(image, "://") {
+		image = "docker://" + image
+	}
+
+	return image
+}
+
+// responseErr returns the errors reported by bblfshd in a response, if any
+func responseErr(res *drivers.Response) error {
+	if len(res.Errors) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(res.Errors, "; "))
+}
+
+// syncDrivers installs, updates and removes drivers until the ones installed
+// in bblfshd match the drivers declared in the config. It does nothing if the
+// config does not declare any driver.
+func (s *Server) syncDrivers(ctx context.Context) error {
+	if len(s.config.Drivers) == 0 {
+		return nil
+	}
+
+	client, release, err := s.dialBblfshDriverClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// bblfshd may not be accepting connections yet right after it is started
+	states, err := client.DriverStates(ctx, &drivers.DriverStatesRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		return errors.Wrap(err, "could not list drivers from bblfsh")
+	}
+
+	declared := make(map[string]api.Driver, len(s.config.Drivers))
+	for _, d := range s.config.Drivers {
+		declared[d.Lang] = d
+	}
+
+	for _, info := range compareDrivers(s.config.Drivers, states.State) {
+		var res *drivers.Response
+
+		switch info.ConfigStatus {
+		case api.ListDriversResponse_MISSING, api.ListDriversResponse_OUTDATED:
+			d := declared[info.Lang]
+			log.Infof("installing driver for %s from %s", d.Lang, d.ImageReference())
+
+			res, err = client.InstallDriver(ctx, &drivers.InstallDriverRequest{
+				Language:       d.Lang,
+				ImageReference: driverImageReference(d.Lang, d.ImageReference()),
+				Update:         info.ConfigStatus == api.ListDriversResponse_OUTDATED,
+			})
+		case api.ListDriversResponse_UNDECLARED:
+			log.Infof("removing driver for %s, it is not declared in the config", info.Lang)
+
+			res, err = client.RemoveDriver(ctx, &drivers.RemoveDriverRequest{
+				Language: info.Lang,
+			})
+		default:
+			continue
+		}
+
+		if err == nil {
+			err = responseErr(res)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not sync driver for %s", info.Lang)
+		}
+	}
+
+	return nil
+}
+
+// compareDrivers returns the installed drivers info, comparing them with the
+// drivers declared in the config. Declared drivers that are not installed are
+// included as missing.
+func compareDrivers(
+	declared []api.Driver,
+	installed []*drivers.DriverImageState,
+) []*api.ListDriversResponse_DriverInfo {
+	byLang := make(map[string]api.Driver, len(declared))
+	for _, d := range declared {
+		byLang[d.Lang] = d
+	}
+
+	var list []*api.ListDriversResponse_DriverInfo
+	seen := make(map[string]bool, len(installed))
+	for _, state := range installed {
+		seen[state.Language] = true
+
+		info := &api.ListDriversResponse_DriverInfo{
 			Lang:    state.Language,
 			Version: state.Version,
+		}
+
+		d, ok := byLang[state.Language]
+		switch {
+		case len(declared) == 0:
+			info.ConfigStatus = api.ListDriversResponse_UNMANAGED
+		case !ok:
+			info.ConfigStatus = api.ListDriversResponse_UNDECLARED
+		case isDriverImage(d, state.Reference) && isDriverVersion(d, state):
+			info.ConfigStatus = api.ListDriversResponse_OK
+			info.ConfigVersion = d.Version
+			info.ConfigImage = d.ImageReference()
+		default:
+			info.ConfigStatus = api.ListDriversResponse_OUTDATED
+			info.ConfigVersion = d.Version
+			info.ConfigImage = d.ImageReference()
+		}
+
+		list = append(list, info)
+	}
+
+	for _, d := range declared {
+		if seen[d.Lang] {
+			continue
+		}
+
+		seen[d.Lang] = true
+		list = append(list, &api.ListDriversResponse_DriverInfo{
+			Lang:          d.Lang,
+			ConfigStatus:  api.ListDriversResponse_MISSING,
+			ConfigVersion: d.Version,
+			ConfigImage:   d.ImageReference(),
 		})
 	}
 
-	return &list, nil
+	return list
+}
+
+// isDriverVersion returns true if the installed driver satisfies the declared
+// driver version. An empty declared version accepts any version, and latest
+// requires the driver to be installed from the latest tag.
+func isDriverVersion(d api.Driver, state *drivers.DriverImageState) bool {
+	switch d.Version {
+	case "":
+		return true
+	case "latest":
+		_, tag := splitImageName(imageName(state.Reference))
+		return tag == "latest"
+	default:
+		return strings.TrimPrefix(d.Version, "v") == strings.TrimPrefix(state.Version, "v")
+	}
+}
+
+// isDriverImage returns true if the driver was installed from the declared
+// driver image, with any tag
+func isDriverImage(d api.Driver, ref string) bool {
+	declared, _ := splitImageName(d.ImageReference())
+	installed, _ := splitImageName(imageName(ref))
+	return normalizeImage(declared) == normalizeImage(installed)
+}
+
+// splitImageName splits an image name like bblfsh/go-driver:latest into the
+// repository and the tag. The tag is latest if the name does not have one.
+func splitImageName(name string) (string, string) {
+	i := strings.LastIndex(name, ":")
+	if i < 0 || strings.Contains(name[i:], "/") {
+		return name, "latest"
+	}
+
+	return name[:i], name[i+1:]
+}
+
+// normalizeImage removes the default docker registry from an image repository,
+// e.g. for docker.io/bblfsh/go-driver it returns bblfsh/go-driver
+func normalizeImage(repo string) string {
+	for _, prefix := range []string{"docker.io/", "index.docker.io/"} {
+		repo = strings.TrimPrefix(repo, prefix)
+	}
+
+	return strings.TrimPrefix(repo, "library/")
 }
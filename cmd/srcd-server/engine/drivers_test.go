@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"testing"
+
+	drivers "github.com/bblfsh/bblfshd/daemon/protocol"
+	"github.com/src-d/engine/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareDrivers(t *testing.T) {
+	require := require.New(t)
+
+	installed := []*drivers.DriverImageState{
+		{Language: "go", Version: "v2.5.2", Reference: "docker://bblfsh/go-driver:v2.5.2"},
+		{Language: "python", Version: "v2.8.2", Reference: "docker://bblfsh/python-driver:v2.8.2"},
+		{Language: "ruby", Version: "v2.9.2", Reference: "docker://bblfsh/ruby-driver:v2.9.2"},
+		{Language: "java", Version: "v2.6.2", Reference: "docker://bblfsh/java-driver:latest"},
+		{Language: "bash", Version: "v2.5.0", Reference: "docker://bblfsh/bash-driver:v2.5.0"},
+		{Language: "cpp", Version: "v1.2.0", Reference: "docker://bblfsh/cpp-driver:v1.2.0"},
+		{Language: "csharp", Version: "v1.4.0", Reference: "docker://docker.io/bblfsh/csharp-driver"},
+	}
+
+	declared := []api.Driver{
+		{Lang: "go", Version: "2.5.2"},
+		{Lang: "python", Version: "v2.9.0"},
+		{Lang: "java", Version: "latest"},
+		{Lang: "php", Version: "v2.7.3"},
+		{Lang: "bash", Version: "v2.5.0", Image: "myorg/bash-driver"},
+		{Lang: "cpp", Version: "latest"},
+		{Lang: "csharp"},
+	}
+
+	expected := []*api.ListDriversResponse_DriverInfo{
+		{Lang: "go", Version: "v2.5.2", ConfigStatus: api.ListDriversResponse_OK,
+			ConfigVersion: "2.5.2", ConfigImage: "bblfsh/go-driver:2.5.2"},
+		{Lang: "python", Version: "v2.8.2", ConfigStatus: api.ListDriversResponse_OUTDATED,
+			ConfigVersion: "v2.9.0", ConfigImage: "bblfsh/python-driver:v2.9.0"},
+		{Lang: "ruby", Version: "v2.9.2", ConfigStatus: api.ListDriversResponse_UNDECLARED},
+		{Lang: "java", Version: "v2.6.2", ConfigStatus: api.ListDriversResponse_OK,
+			ConfigVersion: "latest", ConfigImage: "bblfsh/java-driver:latest"},
+		// same version from another image
+		{Lang: "bash", Version: "v2.5.0", ConfigStatus: api.ListDriversResponse_OUTDATED,
+			ConfigVersion: "v2.5.0", ConfigImage: "myorg/bash-driver:v2.5.0"},
+		// not installed from the latest tag
+		{Lang: "cpp", Version: "v1.2.0", ConfigStatus: api.ListDriversResponse_OUTDATED,
+			ConfigVersion: "latest", ConfigImage: "bblfsh/cpp-driver:latest"},
+		{Lang: "csharp", Version: "v1.4.0", ConfigStatus: api.ListDriversResponse_OK,
+			ConfigImage: "bblfsh/csharp-driver:latest"},
+		{Lang: "php", ConfigStatus: api.ListDriversResponse_MISSING,
+			ConfigVersion: "v2.7.3", ConfigImage: "bblfsh/php-driver:v2.7.3"},
+	}
+
+	require.Equal(expected, compareDrivers(declared, installed))
+}
+
+func TestCompareDriversUnmanaged(t *testing.T) {
+	require := require.New(t)
+
+	installed := []*drivers.DriverImageState{
+		{Language: "go", Version: "v2.5.2", Reference: "docker://bblfsh/go-driver:v2.5.2"},
+	}
+
+	expected := []*api.ListDriversResponse_DriverInfo{
+		{Lang: "go", Version: "v2.5.2", ConfigStatus: api.ListDriversResponse_UNMANAGED},
+	}
+
+	require.Equal(expected, compareDrivers(nil, installed))
+}
+
+func TestImageName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("bblfsh/go-driver:latest", imageName("docker://bblfsh/go-driver:latest"))
+	require.Equal("bblfsh/go-driver:latest", imageName("bblfsh/go-driver:latest"))
+}
+
+func TestSplitImageName(t *testing.T) {
+	require := require.New(t)
+
+	for _, c := range []struct{ name, repo, tag string }{
+		{"bblfsh/go-driver:v2.5.2", "bblfsh/go-driver", "v2.5.2"},
+		{"bblfsh/go-driver", "bblfsh/go-driver", "latest"},
+		{"localhost:5000/go-driver", "localhost:5000/go-driver", "latest"},
+		{"localhost:5000/go-driver:dev", "localhost:5000/go-driver", "dev"},
+	} {
+		repo, tag := splitImageName(c.name)
+		require.Equal(c.repo, repo, c.name)
+		require.Equal(c.tag, tag, c.name)
+	}
+}
@@ -2,10 +2,10 @@ package engine
 
 import (
 	"context"
-	"crypto/sha1"
-	"encoding/hex"
+	"sync"
 
 	api "github.com/src-d/engine/api"
+	"github.com/src-d/engine/components"
 )
 
 var _ api.EngineServer = new(Server)
@@ -16,19 +16,55 @@ type Server struct {
 	hostOS      string
 	workdirHash string
 	config      api.Config
+
+	// parseErrors keeps the last parsing error seen for each language
+	parseErrorsMu sync.RWMutex
+	parseErrors   map[string]string
+
+	// conns keeps the connections to the components
+	conns *connPool
+
+	// querySlots limits the number of concurrent SQL queries, nil if there
+	// is no limit
+	querySlots chan struct{}
+
+	// ready keeps the last readiness seen for each health service
+	readyMu sync.RWMutex
+	ready   map[string]bool
+	// healthWake receives the names of the containers whose health must be
+	// checked right away
+	healthWake chan string
+
+	// events keeps the lifecycle events of the components
+	events *eventBus
 }
 
 func NewServer(version, workdir, hostOS string, config api.Config) *Server {
-	h := sha1.Sum([]byte(workdir))
+	var querySlots chan struct{}
+	if n := config.SQL.MaxConcurrentQueries; n > 0 {
+		querySlots = make(chan struct{}, n)
+	}
+
 	return &Server{
 		version:     version,
 		workdir:     workdir,
 		hostOS:      hostOS,
-		workdirHash: hex.EncodeToString(h[:]),
+		workdirHash: components.WorkdirHash(workdir),
 		config:      config,
+		parseErrors: make(map[string]string),
+		conns:       newConnPool(),
+		querySlots:  querySlots,
+		ready:       make(map[string]bool),
+		healthWake:  make(chan string, healthWakeSize),
+		events:      newEventBus(eventsHistorySize),
 	}
 }
 
+// Close closes the connections to the components
+func (s *Server) Close() error {
+	return s.conns.Close()
+}
+
 func (s *Server) Version(ctx context.Context, req *api.VersionRequest) (*api.VersionResponse, error) {
 	return &api.VersionResponse{Version: s.version}, nil
 }
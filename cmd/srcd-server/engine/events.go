@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/docker"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+const (
+	// eventsHistorySize is the number of past events kept in memory
+	eventsHistorySize = 500
+	// eventsBufferSize is the number of events a watcher can fall behind
+	// before the next ones are dropped
+	eventsBufferSize = 100
+)
+
+// eventBus keeps a bounded history of the events and sends the new ones to
+// the watchers
+type eventBus struct {
+	mu       sync.Mutex
+	lastID   uint64
+	size     int
+	history  []*api.Event
+	watchers map[chan *api.Event]struct{}
+}
+
+func newEventBus(size int) *eventBus {
+	return &eventBus{
+		size:     size,
+		watchers: make(map[chan *api.Event]struct{}),
+	}
+}
+
+// publish sets the id and time of the event, adds it to the history and
+// sends it to the watchers. Slow watchers miss the event instead of blocking
+// the publisher. The pull progress events are not added to the history, they
+// are only meaningful while the pull is in progress.
+func (b *eventBus) publish(e *api.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e.Id = b.lastID
+	if e.Time == 0 {
+		e.Time = time.Now().UnixNano()
+	}
+
+	if e.Type != api.Event_PULL_PROGRESS {
+		b.history = append(b.history, e)
+		if len(b.history) > b.size {
+			b.history = b.history[len(b.history)-b.size:]
+		}
+	}
+
+	for ch := range b.watchers {
+		select {
+		case ch <- e:
+		default:
+			log.Warningf("event watcher is too slow, dropping event %d", e.Id)
+		}
+	}
+}
+
+// watch returns a channel with the new events and, if history is true, the
+// events published before. The returned function must be called to stop
+// watching.
+func (b *eventBus) watch(history bool) ([]*api.Event, <-chan *api.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var past []*api.Event
+	if history {
+		past = make([]*api.Event, len(b.history))
+		copy(past, b.history)
+	}
+
+	ch := make(chan *api.Event, eventsBufferSize)
+	b.watchers[ch] = struct{}{}
+
+	return past, ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.watchers, ch)
+	}
+}
+
+var dockerEventTypes = map[docker.EventType]api.Event_Type{
+	docker.EventPulling:      api.Event_PULLING,
+	docker.EventPullProgress: api.Event_PULL_PROGRESS,
+	docker.EventPulled:       api.Event_PULLED,
+	docker.EventCreated:      api.Event_CREATED,
+	docker.EventStarted:      api.Event_STARTED,
+	docker.EventRemoved:      api.Event_REMOVED,
+}
+
+// HandleDockerEvent publishes the changes made to the docker images and
+// containers, and retires the connections to the containers that changed. It
+// is meant to be set with docker.SetEventHandler.
+func (s *Server) HandleDockerEvent(e docker.Event) {
+	if e.Type == docker.EventStarted || e.Type == docker.EventRemoved {
+		s.conns.retire(e.Container)
+	}
+
+	layers := make([]*api.Event_Layer, len(e.Layers))
+	for i, l := range e.Layers {
+		layers[i] = &api.Event_Layer{
+			Id:      l.ID,
+			Status:  l.Status,
+			Current: l.Current,
+			Total:   l.Total,
+		}
+	}
+
+	s.events.publish(&api.Event{
+		Type:      dockerEventTypes[e.Type],
+		Component: e.Container,
+		Image:     e.Image,
+		Layers:    layers,
+	})
+
+	if e.Type == docker.EventStarted || e.Type == docker.EventRemoved {
+		s.wakeHealthCheck(e.Container)
+	}
+}
+
+func (s *Server) WatchEvents(req *api.WatchEventsRequest, stream api.Engine_WatchEventsServer) error {
+	past, events, stop := s.events.watch(req.History)
+	defer stop()
+
+	for _, e := range past {
+		if err := stream.Send(e); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-events:
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
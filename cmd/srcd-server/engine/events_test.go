@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/src-d/engine/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBus(t *testing.T) {
+	require := require.New(t)
+
+	b := newEventBus(2)
+	b.publish(&api.Event{Type: api.Event_PULLING})
+	b.publish(&api.Event{Type: api.Event_PULLED})
+	b.publish(&api.Event{Type: api.Event_CREATED})
+
+	past, events, stop := b.watch(true)
+	require.Len(past, 2)
+	require.Equal(uint64(2), past[0].Id)
+	require.Equal(api.Event_PULLED, past[0].Type)
+	require.Equal(uint64(3), past[1].Id)
+	require.NotZero(past[1].Time)
+
+	b.publish(&api.Event{Type: api.Event_STARTED})
+	e := <-events
+	require.Equal(uint64(4), e.Id)
+	require.Equal(api.Event_STARTED, e.Type)
+
+	stop()
+	b.publish(&api.Event{Type: api.Event_REMOVED})
+	require.Len(events, 0)
+
+	past, _, stop = b.watch(false)
+	defer stop()
+	require.Empty(past)
+}
+
+func TestEventBusPullProgress(t *testing.T) {
+	require := require.New(t)
+
+	b := newEventBus(10)
+	_, events, stop := b.watch(false)
+	defer stop()
+
+	b.publish(&api.Event{Type: api.Event_PULLING})
+	b.publish(&api.Event{Type: api.Event_PULL_PROGRESS})
+	b.publish(&api.Event{Type: api.Event_PULLED})
+	require.Len(events, 3)
+
+	past, _, stop := b.watch(true)
+	defer stop()
+	require.Len(past, 2)
+	require.Equal(api.Event_PULLING, past[0].Type)
+	require.Equal(api.Event_PULLED, past[1].Type)
+}
@@ -0,0 +1,369 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/docker"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// errDependencyFailed is the error of a component that is not started because
+// one of its dependencies failed. It is never returned by Run, the error of
+// the dependency is.
+var errDependencyFailed = errors.New("a dependency could not be started")
+
+// CycleError is returned by Run when the components depend on each other
+type CycleError struct {
+	// Components is the path of the cycle, the first and last names are
+	// the same
+	Components []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle between components: %s",
+		strings.Join(e.Components, " -> "))
+}
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+// sortComponents returns the given components and all their dependencies,
+// each one only once and after all its dependencies. A dependency without a
+// Start function refers to the component of the same name defined elsewhere
+// in the graph. It fails if a component is not defined, or if there is a
+// dependency cycle.
+func sortComponents(cs []Component) ([]Component, error) {
+	defs := make(map[string]Component)
+	var collect func(cs []Component)
+	collect = func(cs []Component) {
+		for _, c := range cs {
+			if _, ok := defs[c.Name]; !ok && c.Start != nil {
+				defs[c.Name] = c
+			}
+
+			collect(c.Dependencies)
+		}
+	}
+	collect(cs)
+
+	var sorted []Component
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					cycle := append([]string{}, path[i:]...)
+					return &CycleError{Components: append(cycle, name)}
+				}
+			}
+		}
+
+		c, ok := defs[name]
+		if !ok {
+			return fmt.Errorf("unknown component %s", name)
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, d := range c.Dependencies {
+			if err := visit(d.Name); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		sorted = append(sorted, c)
+		return nil
+	}
+
+	for _, c := range cs {
+		if err := visit(c.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// runNode is the state of a component during a run
+type runNode struct {
+	Component
+	// done is closed when the component is ready or failed
+	done chan struct{}
+	err  error
+}
+
+// containerOwners serializes the starts of each container between the runs,
+// and keeps which run started each container, so a failed run does not
+// remove a container that another run found running and is using
+type containerOwners struct {
+	mu     sync.Mutex
+	locks  map[string]*sync.Mutex
+	owners map[string]*runner
+}
+
+func newContainerOwners() *containerOwners {
+	return &containerOwners{
+		locks:  make(map[string]*sync.Mutex),
+		owners: make(map[string]*runner),
+	}
+}
+
+// startedContainers are the owners of the containers started by the runs of
+// the daemon
+var startedContainers = newContainerOwners()
+
+// lock locks the container with the given name, and returns the function
+// that unlocks it
+func (o *containerOwners) lock(name string) func() {
+	o.mu.Lock()
+	l, ok := o.locks[name]
+	if !ok {
+		l = new(sync.Mutex)
+		o.locks[name] = l
+	}
+	o.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// own records that the run started the container
+func (o *containerOwners) own(name string, r *runner) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.owners[name] = r
+}
+
+// share records that the run uses a running container, so the run that
+// started it must not remove it
+func (o *containerOwners) share(name string, r *runner) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if owner, ok := o.owners[name]; ok && owner != r {
+		delete(o.owners, name)
+	}
+}
+
+// owned returns whether the container was started by the run and is not
+// used by any other
+func (o *containerOwners) owned(name string, r *runner) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.owners[name] == r
+}
+
+// release forgets the containers started by a finished run
+func (o *containerOwners) release(r *runner) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for name, owner := range o.owners {
+		if owner == r {
+			delete(o.owners, name)
+		}
+	}
+}
+
+// runner starts a sorted graph of components, each one as soon as its
+// dependencies are ready, and removes the containers it started if any of
+// them fails
+type runner struct {
+	// the docker functions used to run the components, replaced in the tests
+	ensureInstalled func(image, version string) error
+	isRunning       func(name, image string) (bool, error)
+	waitReady       func(ctx context.Context, name string, probe docker.ReadyFunc, timeout time.Duration) error
+	removeContainer func(name string) error
+	owners          *containerOwners
+
+	nodes  map[string]*runNode
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	err     error
+	started []string
+}
+
+func newRunner() *runner {
+	return &runner{
+		ensureInstalled: docker.EnsureInstalled,
+		isRunning:       docker.IsRunning,
+		waitReady:       docker.WaitReady,
+		removeContainer: docker.RemoveContainer,
+		owners:          startedContainers,
+	}
+}
+
+func runSorted(ctx context.Context, sorted []Component) error {
+	return newRunner().runSorted(ctx, sorted)
+}
+
+func (r *runner) runSorted(ctx context.Context, sorted []Component) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r.nodes = make(map[string]*runNode, len(sorted))
+	r.cancel = cancel
+	for _, c := range sorted {
+		r.nodes[c.Name] = &runNode{Component: c, done: make(chan struct{})}
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range sorted {
+		n := r.nodes[c.Name]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(n.done)
+
+			n.err = r.run(ctx, n)
+			if n.err != nil && n.err != errDependencyFailed {
+				r.fail(n.err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if r.err != nil {
+		r.rollback()
+	}
+
+	r.owners.release(r)
+	return r.err
+}
+
+// run pulls the image of the component, that does not depend on anything,
+// waits for its dependencies to be ready, and starts the component if it is
+// not running yet
+func (r *runner) run(ctx context.Context, n *runNode) error {
+	if n.Image != "" {
+		if err := r.ensureInstalled(n.Image, n.Version); err != nil {
+			return &ComponentError{Name: n.Name, Err: err}
+		}
+	}
+
+	for _, d := range n.Dependencies {
+		dep := r.nodes[d.Name]
+		select {
+		case <-dep.done:
+		case <-ctx.Done():
+			return errDependencyFailed
+		}
+
+		if dep.err != nil {
+			return errDependencyFailed
+		}
+	}
+
+	if err := r.start(ctx, n); err != nil {
+		return err
+	}
+
+	if err := r.waitComponentReady(ctx, n.Component); err != nil {
+		return &ComponentError{Name: n.Name, Err: err}
+	}
+
+	return nil
+}
+
+// start starts the component if it is not running. The check and the start
+// are done holding the lock of the container, so concurrent runs don't start
+// it twice.
+func (r *runner) start(ctx context.Context, n *runNode) error {
+	unlock := r.owners.lock(n.Name)
+	defer unlock()
+
+	running, err := r.isRunning(n.Name, "")
+	if err != nil {
+		return &ComponentError{Name: n.Name, Err: err}
+	}
+
+	if running {
+		r.owners.share(n.Name, r)
+		return nil
+	}
+
+	// the container is removed on failure even if it was only created
+	r.addStarted(n.Name)
+	r.owners.own(n.Name, r)
+	if err := n.Start(ctx); err != nil {
+		return &ComponentError{
+			Name: n.Name,
+			Err:  errors.Wrapf(err, "could not create %s", n.Name),
+		}
+	}
+
+	return nil
+}
+
+// waitComponentReady waits for the probe of the component to succeed, for
+// its ReadyTimeout or api.DefaultReadyTimeout
+func (r *runner) waitComponentReady(ctx context.Context, c Component) error {
+	if c.Ready == nil {
+		return nil
+	}
+
+	timeout := c.ReadyTimeout
+	if timeout == 0 {
+		timeout = api.DefaultReadyTimeout
+	}
+
+	return r.waitReady(ctx, c.Name, c.Ready, timeout)
+}
+
+func (r *runner) addStarted(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = append(r.started, name)
+}
+
+// fail keeps the first error of the run and stops the other components
+func (r *runner) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err == nil {
+		r.err = err
+		r.cancel()
+	}
+}
+
+// rollback removes the containers started by the run, the dependants first.
+// The containers used by other runs in the meantime are kept.
+func (r *runner) rollback() {
+	for i := len(r.started) - 1; i >= 0; i-- {
+		r.remove(r.started[i])
+	}
+}
+
+func (r *runner) remove(name string) {
+	unlock := r.owners.lock(name)
+	defer unlock()
+
+	if !r.owners.owned(name, r) {
+		log.Infof("keeping %s, used by another run", name)
+		return
+	}
+
+	log.Infof("removing %s, started by a failed run", name)
+	err := r.removeContainer(name)
+	if err != nil && err != docker.ErrNotFound {
+		log.Errorf(err, "could not remove %s", name)
+	}
+}
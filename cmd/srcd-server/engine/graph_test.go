@@ -0,0 +1,395 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/require"
+)
+
+func startNothing(context.Context) error { return nil }
+
+func componentNames(cs []Component) []string {
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = c.Name
+	}
+
+	return names
+}
+
+func TestSortComponents(t *testing.T) {
+	require := require.New(t)
+
+	bblfshd := Component{Name: "bblfshd", Start: startNothing}
+	gitbase := Component{Name: "gitbase", Start: startNothing, Dependencies: []Component{bblfshd}}
+	bblfshWeb := Component{Name: "bblfsh-web", Start: startNothing, Dependencies: []Component{bblfshd}}
+	gitbaseWeb := Component{Name: "gitbase-web", Start: startNothing, Dependencies: []Component{gitbase}}
+
+	sorted, err := sortComponents([]Component{gitbaseWeb, bblfshWeb})
+	require.NoError(err)
+	require.Equal([]string{"bblfshd", "gitbase", "gitbase-web", "bblfsh-web"}, componentNames(sorted))
+}
+
+func TestSortComponentsReference(t *testing.T) {
+	require := require.New(t)
+
+	// a dependency without Start refers to the component defined elsewhere
+	gitbase := Component{Name: "gitbase", Start: startNothing}
+	analysis := Component{
+		Name:         "analysis",
+		Start:        startNothing,
+		Dependencies: []Component{{Name: "gitbase"}},
+	}
+
+	sorted, err := sortComponents([]Component{analysis, gitbase})
+	require.NoError(err)
+	require.Equal([]string{"gitbase", "analysis"}, componentNames(sorted))
+	require.NotNil(sorted[0].Start)
+
+	_, err = sortComponents([]Component{analysis})
+	require.EqualError(err, "unknown component gitbase")
+}
+
+func TestSortComponentsCycle(t *testing.T) {
+	require := require.New(t)
+
+	a := Component{Name: "a", Start: startNothing, Dependencies: []Component{{Name: "b"}}}
+	b := Component{Name: "b", Start: startNothing, Dependencies: []Component{{Name: "c"}}}
+	c := Component{Name: "c", Start: startNothing, Dependencies: []Component{{Name: "a"}}}
+
+	_, err := sortComponents([]Component{a, b, c})
+	require.Error(err)
+
+	cerr, ok := err.(*CycleError)
+	require.True(ok)
+	require.Equal([]string{"a", "b", "c", "a"}, cerr.Components)
+	require.EqualError(err, "dependency cycle between components: a -> b -> c -> a")
+}
+
+// fakeDocker keeps the state of the containers of a runner in memory and
+// records the calls made to it
+type fakeDocker struct {
+	mu       sync.Mutex
+	running  map[string]bool
+	calls    []string
+	timeouts map[string]time.Duration
+	owners   *containerOwners
+}
+
+func newFakeDocker(running ...string) *fakeDocker {
+	d := &fakeDocker{
+		running:  make(map[string]bool),
+		timeouts: make(map[string]time.Duration),
+		owners:   newContainerOwners(),
+	}
+	for _, name := range running {
+		d.running[name] = true
+	}
+
+	return d
+}
+
+func (d *fakeDocker) record(call string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls = append(d.calls, call)
+}
+
+func (d *fakeDocker) recorded() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string{}, d.calls...)
+}
+
+func (d *fakeDocker) isRunning(name string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.running[name]
+}
+
+// start returns the Start function of a component, that fails with err if it
+// is not nil
+func (d *fakeDocker) start(name string, err error) func(context.Context) error {
+	return func(context.Context) error {
+		d.record("start " + name)
+		if err != nil {
+			return err
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.running[name] = true
+		return nil
+	}
+}
+
+func (d *fakeDocker) runner() *runner {
+	return &runner{
+		ensureInstalled: func(image, version string) error { return nil },
+		isRunning: func(name, image string) (bool, error) {
+			return d.isRunning(name), nil
+		},
+		waitReady: func(ctx context.Context, name string, probe docker.ReadyFunc, timeout time.Duration) error {
+			d.mu.Lock()
+			d.timeouts[name] = timeout
+			d.mu.Unlock()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			for {
+				err := probe(ctx)
+				if err == nil {
+					d.record("ready " + name)
+					return nil
+				}
+
+				if !d.isRunning(name) {
+					return fmt.Errorf("%s stopped before becoming ready", name)
+				}
+
+				select {
+				case <-ctx.Done():
+					return &docker.NotReadyError{Name: name, Timeout: timeout, Err: err}
+				case <-time.After(time.Millisecond):
+				}
+			}
+		},
+		removeContainer: func(name string) error {
+			d.record("remove " + name)
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if !d.running[name] {
+				return docker.ErrNotFound
+			}
+
+			delete(d.running, name)
+			return nil
+		},
+		owners: d.owners,
+	}
+}
+
+// readyAfter returns a probe that succeeds after failing n times
+func readyAfter(n int32) docker.ReadyFunc {
+	var calls int32
+	return func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) <= n {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	}
+}
+
+func TestRunWaitsReady(t *testing.T) {
+	require := require.New(t)
+
+	d := newFakeDocker()
+	bblfshd := Component{
+		Name:         "bblfshd",
+		Start:        d.start("bblfshd", nil),
+		Ready:        readyAfter(3),
+		ReadyTimeout: 2 * time.Minute,
+	}
+	gitbase := Component{
+		Name:         "gitbase",
+		Start:        d.start("gitbase", nil),
+		Ready:        readyAfter(0),
+		Dependencies: []Component{bblfshd},
+	}
+
+	sorted, err := sortComponents([]Component{gitbase})
+	require.NoError(err)
+	require.NoError(d.runner().runSorted(context.Background(), sorted))
+
+	// gitbase is started once bblfshd passes its probe
+	require.Equal([]string{
+		"start bblfshd", "ready bblfshd", "start gitbase", "ready gitbase",
+	}, d.recorded())
+	require.Equal(2*time.Minute, d.timeouts["bblfshd"])
+	require.Equal(api.DefaultReadyTimeout, d.timeouts["gitbase"])
+}
+
+func TestRunRunningComponent(t *testing.T) {
+	require := require.New(t)
+
+	d := newFakeDocker("bblfshd")
+	bblfshd := Component{Name: "bblfshd", Start: d.start("bblfshd", nil), Ready: readyAfter(0)}
+
+	require.NoError(d.runner().runSorted(context.Background(), []Component{bblfshd}))
+
+	// a running component is not started again, only probed
+	require.Equal([]string{"ready bblfshd"}, d.recorded())
+}
+
+func TestRunReadyTimeout(t *testing.T) {
+	require := require.New(t)
+
+	d := newFakeDocker()
+	bblfshd := Component{
+		Name:         "bblfshd",
+		Start:        d.start("bblfshd", nil),
+		Ready:        readyAfter(1 << 30),
+		ReadyTimeout: 50 * time.Millisecond,
+	}
+	gitbase := Component{Name: "gitbase", Start: d.start("gitbase", nil), Dependencies: []Component{bblfshd}}
+
+	sorted, err := sortComponents([]Component{gitbase})
+	require.NoError(err)
+	err = d.runner().runSorted(context.Background(), sorted)
+
+	cerr, ok := err.(*ComponentError)
+	require.True(ok, "unexpected error %v", err)
+	require.Equal("bblfshd", cerr.Name)
+	_, ok = cerr.Err.(*docker.NotReadyError)
+	require.True(ok, "unexpected error %v", cerr.Err)
+
+	// the dependant is not started, and bblfshd is removed
+	require.Equal([]string{"start bblfshd", "remove bblfshd"}, d.recorded())
+	require.Equal(50*time.Millisecond, d.timeouts["bblfshd"])
+}
+
+func TestRunStoppedBeforeReady(t *testing.T) {
+	require := require.New(t)
+
+	d := newFakeDocker()
+	// the container exits right after starting
+	start := func(ctx context.Context) error {
+		d.record("start bblfshd")
+		return nil
+	}
+	bblfshd := Component{Name: "bblfshd", Start: start, Ready: readyAfter(1 << 30)}
+
+	done := make(chan error)
+	go func() {
+		done <- d.runner().runSorted(context.Background(), []Component{bblfshd})
+	}()
+
+	select {
+	case err := <-done:
+		require.EqualError(err, "bblfshd stopped before becoming ready")
+	case <-time.After(time.Second):
+		require.FailNow("the run did not fail when the container stopped")
+	}
+}
+
+func TestRunRollback(t *testing.T) {
+	require := require.New(t)
+
+	d := newFakeDocker()
+	bblfshd := Component{Name: "bblfshd", Start: d.start("bblfshd", nil), Ready: readyAfter(0)}
+	gitbase := Component{
+		Name:         "gitbase",
+		Start:        d.start("gitbase", errors.New("port is already allocated")),
+		Dependencies: []Component{bblfshd},
+	}
+
+	sorted, err := sortComponents([]Component{gitbase})
+	require.NoError(err)
+	err = d.runner().runSorted(context.Background(), sorted)
+	require.EqualError(err, "could not create gitbase: port is already allocated")
+
+	// the dependants are removed first
+	require.Equal([]string{
+		"start bblfshd", "ready bblfshd", "start gitbase",
+		"remove gitbase", "remove bblfshd",
+	}, d.recorded())
+	require.False(d.isRunning("bblfshd"))
+	require.Empty(d.owners.owners)
+
+	// the containers that were already running are kept
+	d = newFakeDocker("bblfshd")
+	bblfshd.Start = d.start("bblfshd", nil)
+	gitbase.Start = d.start("gitbase", errors.New("port is already allocated"))
+	gitbase.Dependencies = []Component{bblfshd}
+
+	sorted, err = sortComponents([]Component{gitbase})
+	require.NoError(err)
+	require.Error(d.runner().runSorted(context.Background(), sorted))
+	require.Equal([]string{"ready bblfshd", "start gitbase", "remove gitbase"}, d.recorded())
+	require.True(d.isRunning("bblfshd"))
+}
+
+func TestRunConcurrentStarts(t *testing.T) {
+	require := require.New(t)
+
+	d := newFakeDocker()
+	start := d.start("bblfshd", nil)
+	bblfshd := Component{
+		Name: "bblfshd",
+		Start: func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return start(ctx)
+		},
+	}
+
+	errs := make(chan error)
+	for i := 0; i < 5; i++ {
+		go func() {
+			errs <- d.runner().runSorted(context.Background(), []Component{bblfshd})
+		}()
+	}
+
+	for i := 0; i < 5; i++ {
+		require.NoError(<-errs)
+	}
+
+	// the container is started by only one of the runs
+	require.Equal([]string{"start bblfshd"}, d.recorded())
+}
+
+func TestRunConcurrentRollback(t *testing.T) {
+	require := require.New(t)
+
+	d := newFakeDocker()
+	bblfshd := Component{Name: "bblfshd", Start: d.start("bblfshd", nil), Ready: readyAfter(0)}
+
+	// the first run starts bblfshd and fails after the second one used it
+	failing := make(chan struct{})
+	proceed := make(chan struct{})
+	analysis := Component{
+		Name: "analysis",
+		Start: func(ctx context.Context) error {
+			close(failing)
+			<-proceed
+			return errors.New("image not found")
+		},
+		Dependencies: []Component{bblfshd},
+	}
+
+	gitbase := Component{
+		Name:         "gitbase",
+		Start:        d.start("gitbase", nil),
+		Dependencies: []Component{bblfshd},
+	}
+
+	first, err := sortComponents([]Component{analysis})
+	require.NoError(err)
+	done := make(chan error)
+	go func() {
+		done <- d.runner().runSorted(context.Background(), first)
+	}()
+
+	<-failing
+	second, err := sortComponents([]Component{gitbase})
+	require.NoError(err)
+	require.NoError(d.runner().runSorted(context.Background(), second))
+
+	close(proceed)
+	require.EqualError(<-done, "could not create analysis: image not found")
+
+	// bblfshd is not removed, gitbase depends on it
+	require.True(d.isRunning("bblfshd"))
+	require.True(d.isRunning("gitbase"))
+	require.NotContains(d.recorded(), "remove bblfshd")
+	require.Contains(d.recorded(), "remove analysis")
+}
@@ -0,0 +1,220 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/docker"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+const (
+	// healthCheckInterval is how often the readiness of the running
+	// components is checked
+	healthCheckInterval = time.Second
+	// healthCheckMaxInterval is the longest time between two checks of a
+	// component that is not running. The interval doubles after each check
+	// until the container starts, or a docker event of the container wakes
+	// the check up.
+	healthCheckMaxInterval = 30 * time.Second
+	// healthCheckTimeout is the time a component has to answer a check
+	healthCheckTimeout = time.Second
+	// healthWakeSize is the number of containers waiting to be checked
+	// before their docker events are dropped
+	healthWakeSize = 16
+)
+
+// healthCheck is the readiness probe of a component. It is only called
+// while the component container is running.
+type healthCheck struct {
+	service   string
+	container string
+	image     string
+	running   func() (bool, error)
+	check     func(ctx context.Context) error
+}
+
+// healthState is the state of the checks of a component
+type healthState struct {
+	healthCheck
+	status healthpb.HealthCheckResponse_ServingStatus
+	// interval is the time between the last check and the next one
+	interval time.Duration
+	next     time.Time
+}
+
+func (s *Server) healthChecks() []healthCheck {
+	return []healthCheck{
+		{
+			service:   api.HealthServiceBblfshd,
+			container: bblfshd.Name,
+			image:     bblfshd.ImageWithVersion(),
+			running:   containerRunning(bblfshd.Name),
+			check:     s.checkBblfshd,
+		},
+		{
+			service:   api.HealthServiceGitbase,
+			container: gitbase.Name,
+			image:     gitbase.ImageWithVersion(),
+			running:   containerRunning(gitbase.Name),
+			check:     s.checkGitbase,
+		},
+	}
+}
+
+func containerRunning(name string) func() (bool, error) {
+	return func() (bool, error) {
+		return docker.IsRunning(name, "")
+	}
+}
+
+func (s *Server) checkBblfshd(ctx context.Context) error {
+	client, release, err := s.bblfshClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	_, err = client.NewVersionRequest().Context(ctx).Do()
+	return errors.Wrap(err, "bblfshd is not ready")
+}
+
+func (s *Server) checkGitbase(ctx context.Context) error {
+	db, release, err := s.gitbaseDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return errors.Wrap(db.PingContext(ctx), "gitbase is not ready")
+}
+
+// CheckHealth sets the serving status of the components in the given health
+// server, checking their readiness periodically until the context is
+// cancelled.
+func (s *Server) CheckHealth(ctx context.Context, hs *health.Server) {
+	s.checkHealth(ctx, hs, s.healthChecks())
+}
+
+func (s *Server) checkHealth(ctx context.Context, hs *health.Server, checks []healthCheck) {
+	states := make([]*healthState, len(checks))
+	for i, c := range checks {
+		states[i] = &healthState{
+			healthCheck: c,
+			status:      healthpb.HealthCheckResponse_NOT_SERVING,
+			interval:    healthCheckInterval,
+		}
+		hs.SetServingStatus(c.service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		for _, st := range states {
+			if now.Before(st.next) {
+				continue
+			}
+
+			s.updateHealth(ctx, hs, st)
+			st.next = now.Add(st.interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case name := <-s.healthWake:
+			for _, st := range states {
+				if st.container == name {
+					st.interval = healthCheckInterval
+					st.next = time.Time{}
+				}
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// wakeHealthCheck makes the next health check of the given container happen
+// right away, instead of waiting for the interval of a stopped container
+func (s *Server) wakeHealthCheck(container string) {
+	select {
+	case s.healthWake <- container:
+	default:
+	}
+}
+
+// updateHealth checks the readiness of a component, backing off while its
+// container is not running, and publishes the changes of its status
+func (s *Server) updateHealth(ctx context.Context, hs *health.Server, st *healthState) {
+	status, running := s.checkComponentHealth(ctx, st.healthCheck)
+	switch {
+	case running:
+		st.interval = healthCheckInterval
+	case st.interval*2 > healthCheckMaxInterval:
+		st.interval = healthCheckMaxInterval
+	default:
+		st.interval *= 2
+	}
+
+	if status == st.status {
+		return
+	}
+
+	log.Infof("%s health status changed to %s", st.service, status)
+	st.status = status
+	hs.SetServingStatus(st.service, status)
+	ready := status == healthpb.HealthCheckResponse_SERVING
+	s.setReady(st.service, ready)
+
+	eventType := api.Event_NOT_READY
+	if ready {
+		eventType = api.Event_READY
+	}
+
+	s.events.publish(&api.Event{
+		Type:      eventType,
+		Component: st.container,
+		Image:     st.image,
+	})
+}
+
+// checkComponentHealth returns the serving status of a component, and
+// whether its container is running
+func (s *Server) checkComponentHealth(
+	ctx context.Context,
+	c healthCheck,
+) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	running, err := c.running()
+	if err != nil || !running {
+		return healthpb.HealthCheckResponse_NOT_SERVING, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	if err := c.check(ctx); err != nil {
+		log.Debugf("%s health check failed: %s", c.service, err)
+		return healthpb.HealthCheckResponse_NOT_SERVING, true
+	}
+
+	return healthpb.HealthCheckResponse_SERVING, true
+}
+
+func (s *Server) setReady(service string, ready bool) {
+	s.readyMu.Lock()
+	defer s.readyMu.Unlock()
+	s.ready[service] = ready
+}
+
+// isReady returns whether the last health check of the service succeeded
+func (s *Server) isReady(service string) bool {
+	s.readyMu.RLock()
+	defer s.readyMu.RUnlock()
+	return s.ready[service]
+}
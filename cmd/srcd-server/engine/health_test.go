@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakeComponent is a component whose container state and readiness are set
+// by the tests
+type fakeComponent struct {
+	mu      sync.Mutex
+	running bool
+	ready   bool
+	checks  int
+}
+
+func (c *fakeComponent) set(running, ready bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running = running
+	c.ready = ready
+}
+
+func (c *fakeComponent) healthCheck() healthCheck {
+	return healthCheck{
+		service:   api.HealthServiceGitbase,
+		container: "srcd-cli-gitbase",
+		image:     "srcd/gitbase:v0.20.0",
+		running: func() (bool, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.checks++
+			return c.running, nil
+		},
+		check: func(ctx context.Context) error {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if !c.ready {
+				return errors.New("connection refused")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (c *fakeComponent) numChecks() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checks
+}
+
+func servingStatus(t *testing.T, hs *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	res, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return res.Status
+}
+
+func TestUpdateHealth(t *testing.T) {
+	require := require.New(t)
+
+	s := NewServer("test", "/tmp", "linux", api.Config{})
+	_, events, stop := s.events.watch(false)
+	defer stop()
+
+	hs := health.NewServer()
+	var c fakeComponent
+	st := &healthState{
+		healthCheck: c.healthCheck(),
+		status:      healthpb.HealthCheckResponse_NOT_SERVING,
+		interval:    healthCheckInterval,
+	}
+
+	// not running, the checks back off
+	ctx := context.Background()
+	s.updateHealth(ctx, hs, st)
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, st.status)
+	require.Equal(2*healthCheckInterval, st.interval)
+	for i := 0; i < 10; i++ {
+		s.updateHealth(ctx, hs, st)
+	}
+	require.Equal(healthCheckMaxInterval, st.interval)
+	require.Len(events, 0)
+
+	// running but not ready yet
+	c.set(true, false)
+	s.updateHealth(ctx, hs, st)
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, st.status)
+	require.Equal(healthCheckInterval, st.interval)
+	require.Len(events, 0)
+
+	c.set(true, true)
+	s.updateHealth(ctx, hs, st)
+	require.Equal(healthpb.HealthCheckResponse_SERVING, servingStatus(t, hs, api.HealthServiceGitbase))
+	require.True(s.isReady(api.HealthServiceGitbase))
+	e := <-events
+	require.Equal(api.Event_READY, e.Type)
+	require.Equal("srcd-cli-gitbase", e.Component)
+	require.Equal("srcd/gitbase:v0.20.0", e.Image)
+
+	// no event if the status does not change
+	s.updateHealth(ctx, hs, st)
+	require.Len(events, 0)
+
+	c.set(false, false)
+	s.updateHealth(ctx, hs, st)
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, hs, api.HealthServiceGitbase))
+	require.False(s.isReady(api.HealthServiceGitbase))
+	e = <-events
+	require.Equal(api.Event_NOT_READY, e.Type)
+	require.Equal("srcd-cli-gitbase", e.Component)
+}
+
+func TestCheckHealthWake(t *testing.T) {
+	require := require.New(t)
+
+	s := NewServer("test", "/tmp", "linux", api.Config{})
+	_, events, stop := s.events.watch(false)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hs := health.NewServer()
+	var c fakeComponent
+	go s.checkHealth(ctx, hs, []healthCheck{c.healthCheck()})
+
+	for i := 0; c.numChecks() == 0; i++ {
+		require.True(i < 100, "the health was not checked")
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, hs, api.HealthServiceGitbase))
+
+	// the next check of the stopped container would be in 2s, the docker
+	// event of the container makes it happen right away
+	c.set(true, true)
+	s.HandleDockerEvent(docker.Event{Type: docker.EventStarted, Container: "srcd-cli-gitbase"})
+
+	select {
+	case e := <-events:
+		// the started event itself
+		require.Equal(api.Event_STARTED, e.Type)
+	case <-time.After(time.Second):
+		require.FailNow("started event not published")
+	}
+
+	select {
+	case e := <-events:
+		require.Equal(api.Event_READY, e.Type)
+	case <-time.After(time.Second):
+		require.FailNow("the health check was not woken up")
+	}
+
+	require.Equal(healthpb.HealthCheckResponse_SERVING, servingStatus(t, hs, api.HealthServiceGitbase))
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// logsComponents returns the built-in components whose logs can be
+// requested. Their images can be replaced by the config, so they are read on
+// each call.
+func logsComponents() []components.Component {
+	return []components.Component{
+		components.Gitbase,
+		components.GitbaseWeb,
+		components.Bblfshd,
+		components.BblfshWeb,
+		components.Daemon,
+	}
+}
+
+// shortName returns the name of the component used in the CLI
+func shortName(c components.Component) string {
+	return components.ShortName(c.Name)
+}
+
+// findLogsComponent returns the component of cs with the given short name,
+// container name or image
+func findLogsComponent(name string, cs []components.Component) (components.Component, error) {
+	var names []string
+	for _, c := range cs {
+		if name == shortName(c) || name == c.Name || name == c.Image {
+			return c, nil
+		}
+
+		names = append(names, shortName(c))
+	}
+
+	return components.Component{}, status.Errorf(codes.InvalidArgument,
+		"unknown component %s, it must be one of [%s]", name, strings.Join(names, ", "))
+}
+
+func (s *Server) Logs(req *api.LogsRequest, stream api.Engine_LogsServer) error {
+	cs := append(logsComponents(), s.config.CustomComponents()...)
+	c, err := findLogsComponent(req.Component, cs)
+	if err != nil {
+		return err
+	}
+
+	info, err := docker.Info(c.Name)
+	if err == docker.ErrNotFound {
+		return status.Errorf(codes.NotFound,
+			"%s has no container, it has not been started", shortName(c))
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "could not get container %s", c.Name)
+	}
+
+	ctx := stream.Context()
+	logs, err := docker.Logs(ctx, info.ID, docker.LogsOptions{
+		Follow: req.Follow,
+		Since:  req.Since,
+		Tail:   int(req.Tail),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not get logs of %s", shortName(c))
+	}
+	defer logs.Close()
+
+	_, err = stdcopy.StdCopy(
+		&logsWriter{stream: stream, kind: api.LogsResponse_STDOUT},
+		&logsWriter{stream: stream, kind: api.LogsResponse_STDERR},
+		logs,
+	)
+
+	// a follow stream ends when the client is gone
+	if err != nil && ctx.Err() == nil {
+		return errors.Wrapf(err, "could not read logs of %s", shortName(c))
+	}
+
+	return nil
+}
+
+// logsWriter sends what is written to a logs stream
+type logsWriter struct {
+	stream api.Engine_LogsServer
+	kind   api.LogsResponse_Stream
+}
+
+func (w *logsWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&api.LogsResponse{Stream: w.kind, Data: p})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/src-d/engine/components"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFindLogsComponent(t *testing.T) {
+	require := require.New(t)
+
+	for _, name := range []string{"gitbase", components.Gitbase.Name, components.Gitbase.Image} {
+		c, err := findLogsComponent(name, logsComponents())
+		require.NoError(err)
+		require.Equal(components.Gitbase.Name, c.Name)
+	}
+
+	c, err := findLogsComponent("bblfsh-web", logsComponents())
+	require.NoError(err)
+	require.Equal(components.BblfshWeb.Name, c.Name)
+
+	c, err = findLogsComponent("daemon", logsComponents())
+	require.NoError(err)
+	require.Equal(components.Daemon.Name, c.Name)
+
+	_, err = findLogsComponent("mysql-cli", logsComponents())
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	require.Contains(err.Error(), "gitbase, gitbase-web, bblfshd, bblfsh-web, daemon")
+
+	custom := components.Custom("analysis", "myorg/analysis", "v1.0.0")
+	c, err = findLogsComponent("analysis", append(logsComponents(), custom))
+	require.NoError(err)
+	require.Equal("srcd-cli-analysis", c.Name)
+}
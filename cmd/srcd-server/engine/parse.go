@@ -4,7 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"io"
+	"sync"
 
 	bblfsh "github.com/bblfsh/go-client/v4"
 	"github.com/bblfsh/go-client/v4/tools"
@@ -14,11 +15,12 @@ import (
 	"github.com/src-d/engine/api"
 	"github.com/src-d/engine/components"
 	"github.com/src-d/engine/docker"
-	enry "gopkg.in/src-d/enry.v1"
 	"gopkg.in/src-d/go-log.v1"
 )
 
-var bblfshd = components.Bblfshd
+const bblfshdStorageMountPath = "/var/lib/bblfshd"
+
+var bblfshd = &components.Bblfshd
 
 type logf func(format string, args ...interface{})
 
@@ -47,11 +49,7 @@ func (s *Server) Parse(ctx context.Context, req *api.ParseRequest) (*api.ParseRe
 
 func (s *Server) parse(ctx context.Context, req *api.ParseRequest, log logf) (*api.ParseResponse, error) {
 	log("got parse request")
-	lang := req.Lang
-	if lang == "" {
-		lang = enry.GetLanguage(req.Name, req.Content)
-	}
-	lang = strings.ToLower(lang)
+	lang := requestLang(req)
 	if req.Kind == api.ParseRequest_LANG {
 		return &api.ParseResponse{Lang: lang}, nil
 	}
@@ -62,13 +60,31 @@ func (s *Server) parse(ctx context.Context, req *api.ParseRequest, log logf) (*a
 		return nil, err
 	}
 
-	addr := fmt.Sprintf("%s:%d", bblfshd.Name, components.BblfshParsePort)
-	log("connecting to bblfsh parsing on %s", addr)
-	client, err := bblfsh.NewClient(addr)
+	client, release, err := s.bblfshClient(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not connect to bblfsh")
+		return nil, err
+	}
+	defer release()
+
+	return s.parseUAST(ctx, client, req, lang)
+}
+
+// requestLang returns the language of the request, detecting it from the file
+// name and content if it was not given.
+func requestLang(req *api.ParseRequest) string {
+	if req.Lang == "" {
+		return api.DetectLanguage(req.Name, req.Content)
 	}
 
+	return api.DriverLang(req.Lang)
+}
+
+func (s *Server) parseUAST(
+	ctx context.Context,
+	client *bblfsh.Client,
+	req *api.ParseRequest,
+	lang string,
+) (*api.ParseResponse, error) {
 	mode := bblfsh.Semantic
 	switch req.Mode {
 	case api.ParseRequest_ANNOTATED:
@@ -85,6 +101,7 @@ func (s *Server) parse(ctx context.Context, req *api.ParseRequest, log logf) (*a
 		Mode(mode).
 		UAST()
 	if err != nil {
+		s.setParseError(lang, err)
 		return nil, errors.Wrap(err, "could not parse")
 	}
 
@@ -112,6 +129,173 @@ func (s *Server) parse(ctx context.Context, req *api.ParseRequest, log logf) (*a
 	return resp, nil
 }
 
+const (
+	defaultParseBatchParallelism = 4
+	maxParseBatchParallelism     = 32
+)
+
+// ParseBatch parses the files received in the stream concurrently, sending
+// back a response for each one of them as soon as it's ready. Responses are
+// not guaranteed to be in the same order as the requests.
+func (s *Server) ParseBatch(stream api.Engine_ParseBatchServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.startComponent(ctx, bblfshd.Name); err != nil {
+		return err
+	}
+
+	client, release, err := s.bblfshClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	parallelism := batchParallelism(first.Parallelism)
+	log.Infof("parsing batch of files, parallelism %d", parallelism)
+
+	return runParseBatch(stream, first, parallelism,
+		func(ctx context.Context, req *api.ParseRequest) *api.ParseBatchResponse {
+			return s.parseBatchFile(ctx, client, req)
+		})
+}
+
+// batchParallelism returns the number of files of a batch parsed at the same
+// time for the requested parallelism
+func batchParallelism(requested int32) int {
+	parallelism := int(requested)
+	if parallelism <= 0 {
+		parallelism = defaultParseBatchParallelism
+	}
+	if parallelism > maxParseBatchParallelism {
+		parallelism = maxParseBatchParallelism
+	}
+
+	return parallelism
+}
+
+type parseBatchFunc func(ctx context.Context, req *api.ParseRequest) *api.ParseBatchResponse
+
+// runParseBatch calls parse for the file of the first request and the ones
+// received after it, with the given parallelism, and sends the results to the
+// stream. If a result can't be sent the files not parsed yet are skipped.
+func runParseBatch(
+	stream api.Engine_ParseBatchServer,
+	first *api.ParseBatchRequest,
+	parallelism int,
+	parse parseBatchFunc,
+) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	files := make(chan *api.ParseRequest)
+	results := make(chan *api.ParseBatchResponse)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range files {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				results <- parse(ctx, req)
+			}
+		}()
+	}
+
+	sendErr := make(chan error, 1)
+	go func() {
+		var err error
+		for res := range results {
+			// keep draining the results after an error so workers don't block
+			if err == nil {
+				err = stream.Send(res)
+				if err != nil {
+					cancel()
+				}
+			}
+		}
+		sendErr <- err
+	}()
+
+	recvErr := func() error {
+		req := first
+		for {
+			if req.File != nil {
+				select {
+				case files <- req.File:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			var err error
+			req, err = stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}()
+
+	close(files)
+	wg.Wait()
+	close(results)
+
+	if err := <-sendErr; err != nil {
+		return err
+	}
+	return recvErr
+}
+
+func (s *Server) parseBatchFile(
+	ctx context.Context,
+	client *bblfsh.Client,
+	req *api.ParseRequest,
+) *api.ParseBatchResponse {
+	resp := &api.ParseBatchResponse{Name: req.Name}
+
+	lang := requestLang(req)
+	if req.Kind == api.ParseRequest_LANG {
+		resp.Result = &api.ParseResponse{Kind: api.ParseResponse_FINAL, Lang: lang}
+		return resp
+	}
+
+	res, err := s.parseUAST(ctx, client, req, lang)
+	if err != nil {
+		log.Debugf("could not parse %s: %s", req.Name, err)
+		resp.Error = err.Error()
+		return resp
+	}
+
+	resp.Result = res
+	return resp
+}
+
+func (s *Server) setParseError(lang string, err error) {
+	s.parseErrorsMu.Lock()
+	s.parseErrors[lang] = err.Error()
+	s.parseErrorsMu.Unlock()
+}
+
+func (s *Server) parseError(lang string) string {
+	s.parseErrorsMu.RLock()
+	defer s.parseErrorsMu.RUnlock()
+	return s.parseErrors[lang]
+}
+
 func createBbblfshd(opts ...docker.ConfigOption) docker.StartFunc {
 	return func(ctx context.Context) error {
 		if err := docker.EnsureInstalled(bblfshd.Image, bblfshd.Version); err != nil {
@@ -0,0 +1,157 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/src-d/engine/api"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeParseBatchStream receives the requests and keeps the responses sent,
+// failing the sends after sendOK of them if sendErr is set
+type fakeParseBatchStream struct {
+	grpc.ServerStream
+
+	mu       sync.Mutex
+	reqs     []*api.ParseBatchRequest
+	sent     []*api.ParseBatchResponse
+	sendOK   int
+	sendErr  error
+	received int
+}
+
+func (s *fakeParseBatchStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeParseBatchStream) Recv() (*api.ParseBatchRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.received >= len(s.reqs) {
+		return nil, io.EOF
+	}
+
+	req := s.reqs[s.received]
+	s.received++
+	return req, nil
+}
+
+func (s *fakeParseBatchStream) Send(res *api.ParseBatchResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sendErr != nil && len(s.sent) >= s.sendOK {
+		return s.sendErr
+	}
+
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+func batchRequests(n int) []*api.ParseBatchRequest {
+	reqs := make([]*api.ParseBatchRequest, n)
+	for i := range reqs {
+		reqs[i] = &api.ParseBatchRequest{
+			File: &api.ParseRequest{Name: fmt.Sprintf("file%d.go", i)},
+		}
+	}
+
+	return reqs
+}
+
+func TestBatchParallelism(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(defaultParseBatchParallelism, batchParallelism(0))
+	require.Equal(defaultParseBatchParallelism, batchParallelism(-1))
+	require.Equal(8, batchParallelism(8))
+	require.Equal(maxParseBatchParallelism, batchParallelism(maxParseBatchParallelism+1))
+}
+
+func TestRunParseBatch(t *testing.T) {
+	require := require.New(t)
+
+	reqs := batchRequests(20)
+	stream := &fakeParseBatchStream{reqs: reqs[1:]}
+
+	var running, maxRunning int32
+	parse := func(ctx context.Context, req *api.ParseRequest) *api.ParseBatchResponse {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		res := &api.ParseBatchResponse{Name: req.Name}
+		if req.Name == "file3.go" {
+			res.Error = "could not parse"
+			return res
+		}
+
+		res.Result = &api.ParseResponse{Lang: "go", Uast: [][]byte{[]byte(req.Name)}}
+		return res
+	}
+
+	err := runParseBatch(stream, reqs[0], 3, parse)
+	require.NoError(err)
+	require.True(maxRunning <= 3, "parallelism exceeded: %d", maxRunning)
+
+	require.Len(stream.sent, len(reqs))
+	byName := make(map[string]*api.ParseBatchResponse)
+	for _, res := range stream.sent {
+		byName[res.Name] = res
+	}
+
+	for _, req := range reqs {
+		res, ok := byName[req.File.Name]
+		require.True(ok, "missing response for %s", req.File.Name)
+
+		// a failed file does not stop the batch
+		if req.File.Name == "file3.go" {
+			require.Equal("could not parse", res.Error)
+			require.Nil(res.Result)
+			continue
+		}
+
+		require.Empty(res.Error)
+		require.Equal([][]byte{[]byte(req.File.Name)}, res.Result.Uast)
+	}
+}
+
+func TestRunParseBatchSendError(t *testing.T) {
+	require := require.New(t)
+
+	reqs := batchRequests(100)
+	stream := &fakeParseBatchStream{
+		reqs:    reqs[1:],
+		sendOK:  1,
+		sendErr: fmt.Errorf("transport is closing"),
+	}
+
+	var calls int32
+	parse := func(ctx context.Context, req *api.ParseRequest) *api.ParseBatchResponse {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Millisecond)
+		return &api.ParseBatchResponse{Name: req.Name}
+	}
+
+	err := runParseBatch(stream, reqs[0], 2, parse)
+	require.EqualError(err, "transport is closing")
+	require.Len(stream.sent, 1)
+
+	// the files received after the error are not parsed
+	require.True(atomic.LoadInt32(&calls) < 10, "parsed %d files", calls)
+}
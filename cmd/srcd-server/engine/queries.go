@@ -0,0 +1,229 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/docker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// killLongQueriesInterval is how often the running queries are checked
+// against the max execution time
+const killLongQueriesInterval = 5 * time.Second
+
+// acquireQuerySlot reserves one of the concurrent queries allowed by the
+// config. The returned function releases it.
+func (s *Server) acquireQuerySlot() (func(), error) {
+	if s.querySlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case s.querySlots <- struct{}{}:
+		return func() { <-s.querySlots }, nil
+	default:
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"too many queries running, the limit is %d", cap(s.querySlots))
+	}
+}
+
+// queryTimeout returns the timeout for a query, the one requested by the
+// client, capped to the max execution time of the config. 0 means no timeout
+func (s *Server) queryTimeout(req *api.SQLRequest) time.Duration {
+	timeout := time.Duration(req.TimeoutMs) * time.Millisecond
+	max := s.config.SQL.MaxExecutionTime
+	if max > 0 && (timeout == 0 || timeout > max) {
+		timeout = max
+	}
+
+	return timeout
+}
+
+// errGitbaseNotRunning is returned by KillQuery when gitbase is not running,
+// so there is no query to kill
+var errGitbaseNotRunning = status.Error(codes.FailedPrecondition, "gitbase is not running")
+
+// ListQueries lists the queries running in gitbase. It does not start
+// gitbase, if it is not running there are no queries.
+func (s *Server) ListQueries(
+	ctx context.Context,
+	req *api.ListQueriesRequest,
+) (*api.ListQueriesResponse, error) {
+	running, err := docker.IsRunning(gitbase.Name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if !running {
+		return &api.ListQueriesResponse{}, nil
+	}
+
+	queries, err := s.listQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ListQueriesResponse{Queries: queries}, nil
+}
+
+// KillQuery kills a query running in gitbase, given the id of its
+// connection. It does not start gitbase.
+func (s *Server) KillQuery(
+	ctx context.Context,
+	req *api.KillQueryRequest,
+) (*api.KillQueryResponse, error) {
+	running, err := docker.IsRunning(gitbase.Name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if !running {
+		return nil, errGitbaseNotRunning
+	}
+
+	queries, err := s.listQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, q := range queries {
+		if q.Id == req.Id {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "query %d not found", req.Id)
+	}
+
+	if err := s.killQuery(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return &api.KillQueryResponse{}, nil
+}
+
+// listQueries returns the queries in the gitbase processlist, except the
+// one used to list them
+func (s *Server) listQueries(ctx context.Context) ([]*api.ListQueriesResponse_Query, error) {
+	db, release, err := s.gitbaseDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to gitbase")
+	}
+	defer conn.Close()
+
+	var connID int64
+	err = conn.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&connID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get connection id")
+	}
+
+	rows, err := conn.QueryContext(ctx, "SHOW PROCESSLIST")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list queries")
+	}
+	defer rows.Close()
+
+	var queries []*api.ListQueriesResponse_Query
+	for rows.Next() {
+		var q api.ListQueriesResponse_Query
+		var user, host, database, command, state, query sql.NullString
+
+		// Id, User, Host, db, Command, Time, State, Info
+		err := rows.Scan(&q.Id, &user, &host, &database, &command, &q.Time, &state, &query)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not scan processlist row")
+		}
+
+		if q.Id == connID {
+			continue
+		}
+
+		q.User = user.String
+		q.Host = host.String
+		q.Command = command.String
+		q.State = state.String
+		q.Query = query.String
+		queries = append(queries, &q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not list queries")
+	}
+
+	return queries, nil
+}
+
+func (s *Server) killQuery(ctx context.Context, id int64) error {
+	db, release, err := s.gitbaseDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	log.Infof("killing query in connection %d", id)
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("KILL QUERY %d", id)); err != nil {
+		return errors.Wrapf(err, "could not kill query %d", id)
+	}
+
+	return nil
+}
+
+// KillLongQueries kills the queries running in gitbase for longer than the
+// max execution time of the config, until the context is cancelled. The
+// queries started by the SQL method already have a deadline, this covers the
+// ones from any other gitbase client, like srcd sql.
+func (s *Server) KillLongQueries(ctx context.Context) {
+	max := s.config.SQL.MaxExecutionTime
+	if max <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(killLongQueriesInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		running, err := docker.IsRunning(gitbase.Name, "")
+		if err != nil || !running {
+			continue
+		}
+
+		queries, err := s.listQueries(ctx)
+		if err != nil {
+			log.Debugf("could not list gitbase queries: %s", err)
+			continue
+		}
+
+		for _, q := range queries {
+			if time.Duration(q.Time)*time.Second <= max {
+				continue
+			}
+
+			log.Infof("query %d exceeded the max execution time of %s", q.Id, max)
+			if err := s.killQuery(ctx, q.Id); err != nil {
+				log.Errorf(err, "could not kill query %d", q.Id)
+			}
+		}
+	}
+}
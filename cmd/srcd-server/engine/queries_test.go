@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/src-d/engine/api"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	require := require.New(t)
+
+	s := NewServer("", "/tmp", "linux", api.Config{})
+	require.Equal(time.Duration(0), s.queryTimeout(&api.SQLRequest{}))
+	require.Equal(time.Second, s.queryTimeout(&api.SQLRequest{TimeoutMs: 1000}))
+
+	s.config.SQL.MaxExecutionTime = time.Minute
+	require.Equal(time.Minute, s.queryTimeout(&api.SQLRequest{}))
+	require.Equal(time.Second, s.queryTimeout(&api.SQLRequest{TimeoutMs: 1000}))
+	require.Equal(time.Minute, s.queryTimeout(&api.SQLRequest{TimeoutMs: 3600000}))
+}
+
+func TestAcquireQuerySlot(t *testing.T) {
+	require := require.New(t)
+
+	var config api.Config
+	config.SQL.MaxConcurrentQueries = 2
+	s := NewServer("", "/tmp", "linux", config)
+
+	release1, err := s.acquireQuerySlot()
+	require.NoError(err)
+	_, err = s.acquireQuerySlot()
+	require.NoError(err)
+
+	_, err = s.acquireQuerySlot()
+	require.Error(err)
+	require.Equal(codes.ResourceExhausted, status.Code(err))
+
+	release1()
+	_, err = s.acquireQuerySlot()
+	require.NoError(err)
+}
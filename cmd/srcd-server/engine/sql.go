@@ -2,26 +2,29 @@ package engine
 
 import (
 	"context"
-	"fmt"
-
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
-	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 	"github.com/src-d/engine/api"
 	"github.com/src-d/engine/components"
 	"github.com/src-d/engine/docker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/src-d/go-log.v1"
 )
 
 const (
 	gitbaseMountPath      = "/opt/repos"
 	gitbaseIndexMountPath = "/var/lib/gitbase/index"
+
+	killQueryTimeout = 5 * time.Second
 )
 
 var (
-	gitbase = components.Gitbase
+	gitbase = &components.Gitbase
 )
 
 func (s *Server) SQL(req *api.SQLRequest, stream api.Engine_SQLServer) error {
@@ -30,49 +33,108 @@ func (s *Server) SQL(req *api.SQLRequest, stream api.Engine_SQLServer) error {
 		return err
 	}
 
-	cfg := mysql.Config{
-		User:                 "root",
-		Net:                  "tcp",
-		Addr:                 gitbase.Name,
-		AllowNativePasswords: true,
-		MaxAllowedPacket:     32 << 20, // 32 MiB
+	db, releaseDB, err := s.gitbaseDB(stream.Context())
+	if err != nil {
+		return err
 	}
-	log.Infof("connecting to mysql %q", cfg.FormatDSN())
-	db, err := sql.Open("mysql", cfg.FormatDSN())
+	defer releaseDB()
+
+	release, err := s.acquireQuerySlot()
 	if err != nil {
-		return errors.Wrap(err, "could not connect to gitbase")
+		return err
 	}
-	rows, err := db.Query(req.Query)
+	defer release()
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	if timeout := s.queryTimeout(req); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// a dedicated connection is needed to know which query to kill
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return queryErr(ctx, err, "could not connect to gitbase")
+	}
+	defer conn.Close()
+
+	var connID int64
+	err = conn.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&connID)
 	if err != nil {
-		return errors.Wrap(err, "SQL query failed")
+		return queryErr(ctx, err, "could not get connection id")
 	}
-	columns, err := rows.Columns()
+
+	stop := killQueryOnCancel(ctx, db, connID)
+	defer stop()
+
+	rows, err := conn.QueryContext(ctx, req.Query)
+	if err != nil {
+		return queryErr(ctx, err, "SQL query failed")
+	}
+	defer rows.Close()
+
+	return s.sendRows(ctx, cancel, rows, stream)
+}
+
+// sendRows sends the header of the query result and its rows to the stream.
+// If the rows exceed the max rows of the config the query is cancelled.
+func (s *Server) sendRows(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	rows *sql.Rows,
+	stream api.Engine_SQLServer,
+) error {
+	columnTypes, err := rows.ColumnTypes()
 	if err != nil {
 		return errors.Wrap(err, "could not fetch columns")
 	}
 
-	columnsBytes := make([][]byte, len(columns))
-	for i, c := range columns {
-		columnsBytes[i] = []byte(c)
+	header := &api.SQLResponse_Header{}
+	columnsBytes := make([][]byte, len(columnTypes))
+	for i, c := range columnTypes {
+		// assume the column is nullable if the driver can't tell
+		nullable, ok := c.Nullable()
+		header.Columns = append(header.Columns, &api.SQLResponse_Column{
+			Name:     c.Name(),
+			Type:     c.DatabaseTypeName(),
+			Nullable: nullable || !ok,
+		})
+		columnsBytes[i] = []byte(c.Name())
 	}
 
 	if err := stream.Send(&api.SQLResponse{
-		Row: &api.SQLResponse_Row{Cell: columnsBytes},
+		Header: header,
+		Row:    &api.SQLResponse_Row{Cell: columnsBytes},
 	}); err != nil {
 		return err
 	}
 
-	values := make([]interface{}, len(columns))
+	values := make([]interface{}, len(columnTypes))
 	for i := range values {
 		values[i] = new([]byte)
 	}
+	var n int
 	for rows.Next() {
+		if max := s.config.SQL.MaxRows; max > 0 && n >= max {
+			// kill the query before closing the rows, otherwise all the
+			// remaining rows would be read
+			cancel()
+			return status.Errorf(codes.ResourceExhausted,
+				"query returned more than %d rows, the maximum allowed", max)
+		}
+		n++
+
 		if err := rows.Scan(values...); err != nil {
 			return errors.Wrap(err, "could not scan row")
 		}
 		row := &api.SQLResponse_Row{}
 		for _, v := range values {
-			row.Cell = append(row.Cell, *v.(*[]byte))
+			// NULL values are scanned as a nil slice
+			cell := *v.(*[]byte)
+			row.Cell = append(row.Cell, cell)
+			row.Null = append(row.Null, cell == nil)
 		}
 		if err := stream.Send(&api.SQLResponse{
 			Row: row,
@@ -81,7 +143,55 @@ func (s *Server) SQL(req *api.SQLRequest, stream api.Engine_SQLServer) error {
 		}
 	}
 
-	return errors.Wrap(rows.Err(), "closing row iterator")
+	if err := rows.Err(); err != nil {
+		return queryErr(ctx, err, "closing row iterator")
+	}
+
+	return nil
+}
+
+// killQueryOnCancel kills the query running in the given gitbase connection
+// when the context is cancelled. The returned function must be called once
+// the query is finished, it waits until any pending kill is done so the
+// connection can be safely reused.
+func killQueryOnCancel(ctx context.Context, db *sql.DB, connID int64) func() {
+	done := make(chan struct{})
+	killed := make(chan struct{})
+
+	go func() {
+		defer close(killed)
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			log.Infof("query in connection %d cancelled: %s", connID, ctx.Err())
+
+			ctx, cancel := context.WithTimeout(context.Background(), killQueryTimeout)
+			defer cancel()
+
+			if _, err := db.ExecContext(ctx, fmt.Sprintf("KILL QUERY %d", connID)); err != nil {
+				log.Errorf(err, "could not kill query in connection %d", connID)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-killed
+	}
+}
+
+// queryErr returns a gRPC error with the right code if the query failed
+// because the context was cancelled or its deadline exceeded
+func queryErr(ctx context.Context, err error, msg string) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return status.Error(codes.Canceled, "query cancelled")
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, "query timeout exceeded")
+	default:
+		return errors.Wrap(err, msg)
+	}
 }
 
 func (s *Server) createGitbase(opts ...docker.ConfigOption) docker.StartFunc {
@@ -100,10 +210,10 @@ func (s *Server) createGitbase(opts ...docker.ConfigOption) docker.StartFunc {
 			},
 		}
 
-		// gitbase can be CPU intensive, set a limit of 75% of the host CPU.
-
 		host := &container.HostConfig{}
 
+		// gitbase can be CPU intensive, set a limit of 75% of the host CPU,
+		// unless the config sets its own limit in the options.
 		// Docker Desktop uses a VM, it already has resource limits
 		if s.hostOS == "linux" {
 			ncpu, err := docker.NCPU(ctx)
@@ -111,12 +221,7 @@ func (s *Server) createGitbase(opts ...docker.ConfigOption) docker.StartFunc {
 				return err
 			}
 
-			// Docker option --cpus is not available in the api, but it is a shorthand.
-			// --cpus="1.5" is equivalent to --cpu-period="100000" --cpu-quota="150000"
-			host.Resources = container.Resources{
-				CPUPeriod: 100000,
-				CPUQuota:  int64(float64(ncpu) * 0.75 * 100000),
-			}
+			docker.WithLimits(docker.Limits{CPUs: float64(ncpu) * 0.75})(config, host)
 		}
 
 		docker.ApplyOptions(config, host, opts...)
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/src-d/engine/api"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeGitbase is a database/sql driver that returns the same rows for any
+// query, with the column types and nullability gitbase reports
+type fakeGitbase struct{}
+
+func (fakeGitbase) Open(name string) (driver.Conn, error) {
+	return fakeGitbaseConn{}, nil
+}
+
+type fakeGitbaseConn struct{}
+
+func (fakeGitbaseConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (fakeGitbaseConn) Close() error { return nil }
+
+func (fakeGitbaseConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (fakeGitbaseConn) QueryContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	return &fakeGitbaseRows{rows: [][]driver.Value{
+		{"go", "", int64(1)},
+		{"python", nil, nil},
+	}}, nil
+}
+
+type fakeGitbaseRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeGitbaseRows) Columns() []string {
+	return []string{"lang", "comment", "files"}
+}
+
+func (r *fakeGitbaseRows) ColumnTypeDatabaseTypeName(i int) string {
+	return []string{"TEXT", "TEXT", "BIGINT"}[i]
+}
+
+func (r *fakeGitbaseRows) ColumnTypeNullable(i int) (bool, bool) {
+	return i > 0, true
+}
+
+func (r *fakeGitbaseRows) Close() error { return nil }
+
+func (r *fakeGitbaseRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("fake-gitbase", fakeGitbase{})
+}
+
+type fakeSQLStream struct {
+	grpc.ServerStream
+	sent []*api.SQLResponse
+}
+
+func (s *fakeSQLStream) Send(res *api.SQLResponse) error {
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+func fakeGitbaseRowsResult(t *testing.T) *sql.Rows {
+	db, err := sql.Open("fake-gitbase", "")
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT lang, comment, files FROM languages")
+	require.NoError(t, err)
+	return rows
+}
+
+func TestSendRows(t *testing.T) {
+	require := require.New(t)
+
+	rows := fakeGitbaseRowsResult(t)
+	defer rows.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var s Server
+	stream := &fakeSQLStream{}
+	require.NoError(s.sendRows(ctx, cancel, rows, stream))
+	require.Len(stream.sent, 3)
+
+	header := stream.sent[0]
+	require.Equal([]*api.SQLResponse_Column{
+		{Name: "lang", Type: "TEXT", Nullable: false},
+		{Name: "comment", Type: "TEXT", Nullable: true},
+		{Name: "files", Type: "BIGINT", Nullable: true},
+	}, header.Header.Columns)
+	require.Equal([][]byte{[]byte("lang"), []byte("comment"), []byte("files")}, header.Row.Cell)
+
+	first := stream.sent[1]
+	require.Nil(first.Header)
+	require.Equal([][]byte{[]byte("go"), []byte(""), []byte("1")}, first.Row.Cell)
+	// an empty string is not NULL
+	require.False(first.Row.IsNull(1))
+	require.False(first.Row.IsNull(2))
+
+	second := stream.sent[2]
+	require.Equal("python", string(second.Row.Cell[0]))
+	require.False(second.Row.IsNull(0))
+	require.True(second.Row.IsNull(1))
+	require.True(second.Row.IsNull(2))
+}
+
+func TestSendRowsMaxRows(t *testing.T) {
+	require := require.New(t)
+
+	rows := fakeGitbaseRowsResult(t)
+	defer rows.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var s Server
+	s.config.SQL.MaxRows = 1
+	stream := &fakeSQLStream{}
+	err := s.sendRows(ctx, cancel, rows, stream)
+	require.Equal(codes.ResourceExhausted, status.Code(err))
+	require.Error(ctx.Err())
+
+	// the header and the first row
+	require.Len(stream.sent, 2)
+}
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
+)
+
+// stateNotCreated is the state of a component without container
+const stateNotCreated = "not created"
+
+// statusComponent is a built-in component reported by Status, with the
+// health service that checks its readiness, if any
+type statusComponent struct {
+	component     components.Component
+	healthService string
+}
+
+// statusComponents returns the built-in components reported by Status. Their
+// images can be replaced by the config, so they are read on each call.
+func statusComponents() []statusComponent {
+	return []statusComponent{
+		{components.Daemon, ""},
+		{components.Bblfshd, api.HealthServiceBblfshd},
+		{components.BblfshWeb, ""},
+		{components.Gitbase, api.HealthServiceGitbase},
+		{components.GitbaseWeb, ""},
+	}
+}
+
+func (s *Server) Status(ctx context.Context, req *api.StatusRequest) (*api.StatusResponse, error) {
+	res := &api.StatusResponse{
+		Version:   s.version,
+		Workdir:   s.workdir,
+		HostOs:    s.hostOS,
+		Config:    s.config.AsYaml(),
+		Workspace: components.Workspace(),
+	}
+
+	for _, c := range statusComponents() {
+		cmp, err := s.componentStatus(c.component, c.healthService)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Components = append(res.Components, cmp)
+	}
+
+	for _, c := range s.config.CustomComponents() {
+		cmp, err := s.componentStatus(c, "")
+		if err != nil {
+			return nil, err
+		}
+
+		res.Components = append(res.Components, cmp)
+	}
+
+	return res, nil
+}
+
+func (s *Server) componentStatus(
+	c components.Component,
+	healthService string,
+) (*api.StatusResponse_Component, error) {
+	cmp := &api.StatusResponse_Component{
+		Name:  c.Name,
+		Image: c.ImageWithVersion(),
+		State: stateNotCreated,
+	}
+
+	// the daemon version is only known by the CLI
+	if c.Version == "" {
+		cmp.Image = c.Image
+	}
+
+	info, err := docker.Info(c.Name)
+	if err == docker.ErrNotFound {
+		return cmp, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get container %s", c.Name)
+	}
+
+	cmp.Image = info.Image
+	cmp.State = info.State
+	for _, p := range info.Ports {
+		cmp.Ports = append(cmp.Ports, &api.StatusResponse_Port{
+			HostIp:      p.IP,
+			PublicPort:  int32(p.PublicPort),
+			PrivatePort: int32(p.PrivatePort),
+		})
+	}
+
+	limits, err := docker.GetLimits(c.Name)
+	if err != nil && err != docker.ErrNotFound {
+		return nil, errors.Wrapf(err, "could not get the limits of container %s", c.Name)
+	}
+
+	cmp.Limits = &api.StatusResponse_Limits{
+		Cpus:       limits.CPUs,
+		Memory:     limits.Memory,
+		MemorySwap: limits.MemorySwap,
+		PidsLimit:  limits.PidsLimit,
+	}
+
+	running := info.State == "running"
+	if healthService == "" {
+		cmp.Ready = running
+	} else {
+		cmp.Ready = running && s.isReady(healthService)
+	}
+
+	return cmp, nil
+}
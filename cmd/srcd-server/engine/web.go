@@ -10,11 +10,9 @@ import (
 	"gopkg.in/src-d/go-log.v1"
 )
 
-const gitbaseWebSelectLimit = 0
-
 var (
-	gitbaseWeb = components.GitbaseWeb
-	bblfshWeb  = components.BblfshWeb
+	gitbaseWeb = &components.GitbaseWeb
+	bblfshWeb  = &components.BblfshWeb
 )
 
 func createBblfshWeb(opts ...docker.ConfigOption) docker.StartFunc {
@@ -39,7 +37,9 @@ func createBblfshWeb(opts ...docker.ConfigOption) docker.StartFunc {
 	}
 }
 
-func createGitbaseWeb(opts ...docker.ConfigOption) docker.StartFunc {
+// createGitbaseWeb returns the function to start gitbase-web. selectLimit is
+// the default limit of the rows returned, 0 means no limit.
+func createGitbaseWeb(selectLimit int, opts ...docker.ConfigOption) docker.StartFunc {
 	return func(ctx context.Context) error {
 		if err := docker.EnsureInstalled(gitbaseWeb.Image, gitbaseWeb.Version); err != nil {
 			return err
@@ -56,7 +56,7 @@ func createGitbaseWeb(opts ...docker.ConfigOption) docker.StartFunc {
 				fmt.Sprintf("GITBASEPG_DB_CONNECTION=root@tcp(%s)/none?maxAllowedPacket=4194304", gitbase.Name),
 				fmt.Sprintf("GITBASEPG_BBLFSH_SERVER_URL=%s:%d", bblfshd.Name, components.BblfshParsePort),
 				fmt.Sprintf("GITBASEPG_PORT=%d", components.GitbaseWebPort),
-				fmt.Sprintf("GITBASEPG_SELECT_LIMIT=%d", gitbaseWebSelectLimit),
+				fmt.Sprintf("GITBASEPG_SELECT_LIMIT=%d", selectLimit),
 			},
 		}
 		host := &container.HostConfig{}
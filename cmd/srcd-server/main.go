@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strings"
 
 	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/auth"
 	"github.com/src-d/engine/cmd/srcd-server/engine"
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
 
 	"github.com/pkg/errors"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"gopkg.in/src-d/go-cli.v0"
 	"gopkg.in/src-d/go-log.v1"
 	yaml "gopkg.in/yaml.v2"
@@ -35,6 +43,15 @@ type serveCmd struct {
 	Workdir string `long:"workdir" short:"w" default:""`
 	HostOS  string `long:"host-os" default:""`
 	Config  string `long:"config" short:"c" default:""`
+	// CredentialsDir holds the TLS certificates and the access token, they
+	// are created if they don't exist. If empty the connections are insecure
+	CredentialsDir string `long:"credentials-dir" default:""`
+	// User is the name of the user running srcd, it labels the docker
+	// objects created by the daemon
+	User string `long:"user" default:""`
+	// Workspace names the containers of the components of this daemon, so
+	// the daemons of several workspaces can run at the same time
+	Workspace string `long:"workspace" default:"default"`
 }
 
 func (c *serveCmd) Execute(args []string) error {
@@ -51,15 +68,79 @@ func (c *serveCmd) Execute(args []string) error {
 		}
 	}
 	config.SetDefaults()
+	if err := config.Validate(); err != nil {
+		return errors.Wrapf(err, "Error reading --config option")
+	}
+
+	components.SetWorkspace(c.Workspace)
+	docker.SetOwner(docker.Owner{
+		User:        c.User,
+		Version:     version,
+		WorkdirHash: components.WorkdirHash(workdir),
+		Workspace:   c.Workspace,
+		Shared:      []string{components.Bblfshd.Name, components.BblfshWeb.Name},
+	})
+
+	config.ApplyOverrides()
+	components.SetCustom(config.CustomComponents())
 
 	l, err := net.Listen("tcp", c.Addr)
 	if err != nil {
 		return err
 	}
 
-	srv := grpc.NewServer()
-	api.RegisterEngineServer(srv, engine.NewServer(version, workdir, c.HostOS, config))
+	engineSrv := engine.NewServer(version, workdir, c.HostOS, config)
+	docker.SetEventHandler(engineSrv.HandleDockerEvent)
+	go engineSrv.KillLongQueries(context.Background())
+
+	opts, err := c.serverOptions()
+	if err != nil {
+		return err
+	}
+
+	srv := grpc.NewServer(opts...)
+	api.RegisterEngineServer(srv, engineSrv)
+
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	go engineSrv.CheckHealth(context.Background(), healthSrv)
+
+	reflection.Register(srv)
 
 	log.Infof("listening on %s", c.Addr)
-	return srv.Serve(l)
+	err = srv.Serve(l)
+	if cerr := engineSrv.Close(); cerr != nil {
+		log.Errorf(cerr, "could not close the connections to the components")
+	}
+
+	return err
+}
+
+// serverOptions returns the options to secure the server with TLS and the
+// access token, if a credentials directory was given
+func (c *serveCmd) serverOptions() ([]grpc.ServerOption, error) {
+	if c.CredentialsDir == "" {
+		log.Warningf("no credentials directory given, the connections are not secure")
+		return nil, nil
+	}
+
+	if err := auth.EnsureCredentials(c.CredentialsDir); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := auth.ServerTLSConfig(c.CredentialsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := auth.ReadToken(c.CredentialsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.UnaryInterceptor(auth.UnaryServerInterceptor(token)),
+		grpc.StreamInterceptor(auth.StreamServerInterceptor(token)),
+	}, nil
 }
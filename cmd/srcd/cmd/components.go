@@ -17,8 +17,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	api "github.com/src-d/engine/api"
@@ -26,6 +28,7 @@ import (
 	"github.com/src-d/engine/components"
 	"github.com/src-d/engine/docker"
 
+	"golang.org/x/crypto/ssh/terminal"
 	cli "gopkg.in/src-d/go-cli.v0"
 	log "gopkg.in/src-d/go-log.v1"
 )
@@ -43,6 +46,10 @@ type componentsListCmd struct {
 }
 
 func (c *componentsListCmd) Execute(args []string) error {
+	if _, err := loadConfigComponents(); err != nil {
+		return err
+	}
+
 	components.Daemon.RetrieveVersion()
 
 	cmps, err := components.List(context.Background(), c.All)
@@ -50,14 +57,16 @@ func (c *componentsListCmd) Execute(args []string) error {
 		return humanizef(err, "could not list images")
 	}
 
-	t := NewTable("%s", "%s", "%v", "%v", "%v")
-	t.Header("IMAGE", "INSTALLED", "RUNNING", "PORT", "CONTAINER NAME")
+	t := NewTable("%s", "%s", "%v", "%v", "%v", "%v", "%v")
+	t.Header("IMAGE", "INSTALLED", "RUNNING", "PORT", "VOLUMES", "LIMITS", "CONTAINER NAME")
 	for _, cmp := range cmps {
 		t.Row(
 			cmp.ImageWithVersion(),
 			boolFmt(cmp.IsInstalled()),
 			boolFmt(cmp.IsRunning()),
 			publicPortsFmt(cmp.GetPorts()),
+			volumesFmt(cmp.GetVolumes()),
+			limitsFmt(cmp.GetLimits()),
 			cmp.Name,
 		)
 	}
@@ -83,7 +92,14 @@ func publicPortsFmt(ps []docker.Port, err error) string {
 
 	var publicPorts []string
 	for _, p := range ps {
-		if p.PublicPort != 0 {
+		if p.PublicPort == 0 {
+			continue
+		}
+
+		// show the host address only if the port is not bound to all of them
+		if ip := net.ParseIP(p.IP); ip != nil && !ip.IsUnspecified() {
+			publicPorts = append(publicPorts, fmt.Sprintf("%s:%d", p.IP, p.PublicPort))
+		} else {
 			publicPorts = append(publicPorts, fmt.Sprintf("%d", p.PublicPort))
 		}
 	}
@@ -91,6 +107,22 @@ func publicPortsFmt(ps []docker.Port, err error) string {
 	return strings.Join(publicPorts, ",")
 }
 
+func volumesFmt(vols []string, err error) string {
+	if err != nil {
+		return "?"
+	}
+
+	return strings.Join(vols, ",")
+}
+
+func limitsFmt(l docker.Limits, err error) string {
+	if err != nil {
+		return "?"
+	}
+
+	return l.String()
+}
+
 // componentsInstallCmd represents the components install command
 type componentsInstallCmd struct {
 	Command `name:"install" short-description:"Install source{d} component" long-description:"Install source{d} component"`
@@ -101,11 +133,30 @@ type componentsInstallCmd struct {
 }
 
 func (c *componentsInstallCmd) Execute(args []string) error {
+	if _, err := loadConfigComponents(); err != nil {
+		return err
+	}
+
+	var bars *pullBars
+	if showPullBars(terminal.IsTerminal(int(os.Stderr.Fd()))) {
+		bars = newPullBars(os.Stderr)
+		docker.SetEventHandler(func(e docker.Event) {
+			switch e.Type {
+			case docker.EventPullProgress:
+				bars.Update(e.Image, e.Layers)
+			case docker.EventPulled:
+				bars.Done(e.Image)
+			}
+		})
+		defer docker.SetEventHandler(nil)
+	}
+
 	cmps, err := components.List(context.Background(), false)
 	if err != nil {
 		return humanizef(err, "could not list images")
 	}
 
+	var toInstall []components.Component
 	for _, arg := range c.Args.Components {
 		c, err := getComponent(arg, cmps)
 		if err != nil {
@@ -128,10 +179,25 @@ func (c *componentsInstallCmd) Execute(args []string) error {
 		}
 
 		log.Infof("installing %s", c.ImageWithVersion())
+		toInstall = append(toInstall, *c)
+	}
+
+	// the images are pulled in parallel
+	errs := make([]error, len(toInstall))
+	var wg sync.WaitGroup
+	for i, c := range toInstall {
+		wg.Add(1)
+		go func(i int, c components.Component) {
+			defer wg.Done()
+			errs[i] = c.Install()
+		}(i, c)
+	}
 
-		err = c.Install()
+	wg.Wait()
+
+	for i, err := range errs {
 		if err != nil {
-			return humanizef(err, "could not install %s", arg)
+			return humanizef(err, "could not install %s", toInstall[i].Name)
 		}
 	}
 
@@ -148,6 +214,10 @@ type componentsStartCmd struct {
 }
 
 func (c *componentsStartCmd) Execute(args []string) error {
+	if _, err := loadConfigComponents(); err != nil {
+		return err
+	}
+
 	client, err := daemon.Client()
 	if err != nil {
 		return humanizef(err, "could not get daemon client")
@@ -165,7 +235,7 @@ func (c *componentsStartCmd) Execute(args []string) error {
 		}
 
 		ctx := context.Background()
-		started := logAfterTimeoutWithServerLogs("this is taking a while, "+
+		started := logAfterTimeoutWithServerEvents("this is taking a while, "+
 			"it might take a few more minutes while we install all the required images",
 			5*time.Second)
 		_, err = client.StartComponent(ctx, &api.StartComponentRequest{
@@ -180,10 +250,25 @@ func (c *componentsStartCmd) Execute(args []string) error {
 	return nil
 }
 
+// loadConfigComponents applies the images and versions of the config file of
+// the daemon to the built-in components, and makes components.List return the
+// components declared in it too. It returns the config.
+func loadConfigComponents() (*api.Config, error) {
+	conf, err := daemon.Config()
+	if err != nil {
+		return nil, humanizef(err, "could not read the daemon config")
+	}
+
+	conf.ApplyOverrides()
+	components.SetCustom(conf.CustomComponents())
+	return conf, nil
+}
+
 func getComponent(arg string, cmps []components.Component) (*components.Component, error) {
 	var c *components.Component
 	for _, cmp := range cmps {
-		if arg == cmp.Name || arg == cmp.Image {
+		if arg == cmp.Name || arg == cmp.Image ||
+			arg == components.ShortName(cmp.Name) {
 			c = &cmp
 			break
 		}
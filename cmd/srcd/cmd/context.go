@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/src-d/engine/cmd/srcd/daemon"
+
+	"gopkg.in/src-d/go-cli.v0"
+)
+
+// contextCmd represents the context command
+type contextCmd struct {
+	cli.PlainCommand `name:"context" short-description:"Manage the engines srcd works with" long-description:"Manage the engines srcd works with.\n\nThe default context is the engine run by srcd in the local docker. Other contexts connect to remote engines."`
+}
+
+// contextCommand is embedded by the context subcommands. They do not load
+// the engine context, so they can be used to fix an invalid one.
+type contextCommand struct {
+	Command
+}
+
+// Init implements cli.Initializer
+func (c contextCommand) Init(a *cli.App) error {
+	return c.LogOptions.Init(a)
+}
+
+// contextCreateCmd represents the context create command
+type contextCreateCmd struct {
+	contextCommand `name:"create" short-description:"Create a context for a remote engine" long-description:"Create a context for a remote engine.\n\nThe TLS directory must contain the ca.pem and token files of the remote daemon, found in ~/.srcd/tls on its host. They are copied into the context."`
+
+	Address    string `long:"address" required:"yes" description:"address of the remote daemon, as host:port"`
+	TLSDir     string `long:"tls-dir" required:"yes" description:"directory with the ca.pem and token files of the remote daemon"`
+	DockerHost string `long:"docker-host" description:"docker daemon running the remote engine, e.g. tcp://host:2376. It is needed by the commands that manage containers, like sql or components"`
+	WorkDir    string `long:"workdir" description:"label for the working directory served by the remote engine"`
+
+	Args struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *contextCreateCmd) Execute(args []string) error {
+	err := daemon.CreateContext(daemon.Context{
+		Name:       c.Args.Name,
+		Address:    c.Address,
+		DockerHost: c.DockerHost,
+		WorkDir:    c.WorkDir,
+	}, c.TLSDir)
+	if err != nil {
+		return humanizef(err, "could not create context")
+	}
+
+	fmt.Printf("context %s created, run srcd context use %s to use it\n", c.Args.Name, c.Args.Name)
+	return nil
+}
+
+// contextUseCmd represents the context use command
+type contextUseCmd struct {
+	contextCommand `name:"use" short-description:"Set the context used by srcd" long-description:"Set the context used by the next srcd commands. It can be overridden with the --context flag or the SRCD_CONTEXT environment variable."`
+
+	Args struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *contextUseCmd) Execute(args []string) error {
+	if err := daemon.UseContext(c.Args.Name); err != nil {
+		return humanizef(err, "could not use context")
+	}
+
+	fmt.Printf("using context %s\n", c.Args.Name)
+	return nil
+}
+
+// contextListCmd represents the context list command
+type contextListCmd struct {
+	contextCommand `name:"list" short-description:"List the contexts" long-description:"List the contexts. The one in use is marked with *"`
+}
+
+func (c *contextListCmd) Execute(args []string) error {
+	contexts, err := daemon.ListContexts()
+	if err != nil {
+		return humanizef(err, "could not list contexts")
+	}
+
+	currentName, err := daemon.CurrentContextName()
+	if err != nil {
+		return humanizef(err, "could not get current context")
+	}
+
+	t := NewTable("%s", "%s", "%s", "%s")
+	t.Header("NAME", "ADDRESS", "DOCKER HOST", "WORKDIR")
+	for _, ctx := range contexts {
+		name := ctx.Name
+		if name == currentName {
+			name += " *"
+		}
+
+		address := ctx.Address
+		dockerHost := ctx.DockerHost
+		if !ctx.IsRemote() {
+			address = "local"
+			dockerHost = "local"
+		}
+
+		t.Row(name, address, dockerHost, ctx.WorkDir)
+	}
+
+	return t.Print(os.Stdout)
+}
+
+func init() {
+	c := rootCmd.AddCommand(&contextCmd{})
+	c.AddCommand(&contextCreateCmd{})
+	c.AddCommand(&contextUseCmd{})
+	c.AddCommand(&contextListCmd{})
+}
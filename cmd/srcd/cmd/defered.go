@@ -25,6 +25,11 @@ type defered struct {
 	Spinner bool
 	// SpinnerInterval allows to change speed of spinner (200ms by default)
 	SpinnerInterval time.Duration
+	// ProgressFn when set is called on every spinner tick, its result is
+	// printed after the message
+	ProgressFn func() string
+	// Bars when set are kept below the messages of InputFn
+	Bars *pullBars
 
 	// logger is the go-log DefaultLogger. Can be changed for tests
 	logger log.Logger
@@ -92,7 +97,13 @@ func (d *defered) print(ch <-chan bool, done chan<- bool) {
 
 	go func() {
 		for line := range d.InputFn(ch) {
-			d.logger.Infof(line)
+			if d.Bars == nil {
+				d.logger.Infof(line)
+				continue
+			}
+
+			line := line
+			d.Bars.Println(func() { d.logger.Infof(line) })
 		}
 
 		done <- true
@@ -115,11 +126,11 @@ func (d *defered) spinner(msg string, stop <-chan bool, done chan<- bool) {
 	// If the logger format is not text, or the output is not a terminal,
 	// do not print the spinner
 	if log.DefaultFactory.Format != log.TextFormat || !d.isTerminal {
-		d.logger.Infof(d.Msg)
+		d.logger.Infof(d.message())
 
 		select {
 		case <-stop:
-			d.logger.Infof("%s, done", d.Msg)
+			d.logger.Infof("%s, done", d.message())
 			done <- true
 			return
 		}
@@ -130,12 +141,12 @@ func (d *defered) spinner(msg string, stop <-chan bool, done chan<- bool) {
 	for {
 		select {
 		case <-stop:
-			d.logger.Infof("%s, done", d.Msg)
+			d.logger.Infof("%s, done", d.message())
 			done <- true
 			return
 		default:
 			spinner := string(charset[i%len(charset)])
-			d.logger.Infof("%s %s", d.Msg, spinner)
+			d.logger.Infof("%s %s", d.message(), spinner)
 			time.Sleep(interval)
 			fmt.Fprint(d.logWriter, "\033[A")
 		}
@@ -146,3 +157,11 @@ func (d *defered) spinner(msg string, stop <-chan bool, done chan<- bool) {
 		}
 	}
 }
+
+func (d *defered) message() string {
+	if d.ProgressFn == nil {
+		return d.Msg
+	}
+
+	return fmt.Sprintf("%s %s", d.Msg, d.ProgressFn())
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io/ioutil"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -138,6 +139,35 @@ func (s *DeferedTestSuite) TestPrintWithSpinner() {
 	})
 }
 
+func (s *DeferedTestSuite) TestPrintWithProgress() {
+	log.DefaultFactory = &log.LoggerFactory{
+		Level:       log.InfoLevel,
+		Format:      log.TextFormat,
+		ForceFormat: true,
+	}
+
+	require := s.Require()
+
+	d := s.buildDefered(true, nil)
+
+	var ticks int32
+	d.ProgressFn = func() string {
+		return fmt.Sprintf("(%d)", atomic.AddInt32(&ticks, 1))
+	}
+
+	s.logAction(d, 500*time.Millisecond)
+
+	expected := []string{
+		"Start",
+		"Hello World! (1) ⠋",
+		"Hello World! (2) ⠙",
+		"Hello World! (3) ⠹",
+		"Hello World! (4), done",
+		"End",
+	}
+	require.Equal(expected, s.mockLogger.msgs)
+}
+
 func (s *DeferedTestSuite) TestPrintWithInputFn() {
 	inputFn := func(stop <-chan bool) <-chan string {
 		ch := make(chan string)
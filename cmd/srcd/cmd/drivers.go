@@ -2,16 +2,24 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	api "github.com/src-d/engine/api"
 	"github.com/src-d/engine/cmd/srcd/daemon"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gopkg.in/src-d/go-log.v1"
 )
 
 // parseDriverCmd represents the parse drivers command
 type parseDriverCmd struct {
 	Command `name:"drivers" short-description:"List installed language drivers" long-description:"List installed language drivers"`
+
+	Status bool `long:"status" description:"show the state of the driver pools and instances"`
 }
 
 func (cmd *parseDriverCmd) Execute(args []string) error {
@@ -24,16 +32,222 @@ func (cmd *parseDriverCmd) Execute(args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
-	drivers, err := c.ListDrivers(ctx, &api.ListDriversRequest{})
+	drivers, err := c.ListDrivers(ctx, &api.ListDriversRequest{Status: cmd.Status})
 	if err != nil {
 		return humanizef(err, "could not list drivers")
 	}
 
-	t := NewTable("%s", "%s")
-	t.Header("LANGUAGE", "VERSION")
+	if cmd.Status {
+		return printDriversStatus(drivers.Drivers)
+	}
+
+	if !isDriversManaged(drivers.Drivers) {
+		t := NewTable("%s", "%s")
+		t.Header("LANGUAGE", "VERSION")
+		for _, driver := range drivers.Drivers {
+			t.Row(driver.Lang, driver.Version)
+		}
+
+		return t.Print(os.Stdout)
+	}
+
+	t := NewTable("%s", "%s", "%s")
+	t.Header("LANGUAGE", "VERSION", "CONFIG")
+	for _, driver := range drivers.Drivers {
+		t.Row(driver.Lang, driver.Version, driverConfigStatusFmt(driver))
+	}
+
+	if err := t.Print(os.Stdout); err != nil {
+		return err
+	}
+
 	for _, driver := range drivers.Drivers {
-		t.Row(driver.Lang, driver.Version)
+		if driver.ConfigStatus != api.ListDriversResponse_OK {
+			warnDriversDrift()
+			break
+		}
+	}
+
+	return nil
+}
+
+// warnDriversDrift warns that the installed drivers do not match the config.
+// The drivers are synced when bblfshd is started, and srcd init does not
+// restart it while the daemon of another workspace is using it.
+func warnDriversDrift() {
+	shared, err := daemon.IsBblfshdShared()
+	if err != nil {
+		log.Debugf("could not check if bblfshd is shared: %s", err)
+	}
+
+	if shared {
+		log.Warningf("installed drivers do not match the config, bblfshd is " +
+			"shared with other workspaces, stop them with " +
+			"srcd stop --workspace <name> and run srcd init to install the declared ones")
+		return
+	}
+
+	log.Warningf("installed drivers do not match the config, " +
+		"run srcd init to install the declared ones")
+}
+
+func printDriversStatus(drivers []*api.ListDriversResponse_DriverInfo) error {
+	t := NewTable("%s", "%s", "%v", "%v", "%v", "%v", "%v", "%v", "%s")
+	t.Header("LANGUAGE", "VERSION", "WANTED", "RUNNING", "WAITING", "SUCCESS", "ERRORS", "EXITED", "LAST ERROR")
+	for _, driver := range drivers {
+		pool := driver.Pool
+		if pool == nil {
+			pool = &api.ListDriversResponse_PoolState{}
+		}
+
+		t.Row(driver.Lang, driver.Version,
+			pool.Wanted, pool.Running, pool.Waiting,
+			pool.Success, pool.Errors, pool.Exited,
+			driver.LastError)
+	}
+
+	if err := t.Print(os.Stdout); err != nil {
+		return err
+	}
+
+	instances := NewTable("%s", "%s", "%s", "%s", "%s", "%s")
+	instances.Header("INSTANCE", "LANGUAGE", "IMAGE", "STATUS", "CREATED", "PROCESSES")
+	var n int
+	for _, driver := range drivers {
+		for _, instance := range driver.Instances {
+			n++
+			processes := make([]string, len(instance.Processes))
+			for i, p := range instance.Processes {
+				processes[i] = fmt.Sprint(p)
+			}
+
+			instances.Row(
+				shortID(instance.Id),
+				driver.Lang,
+				instance.Image,
+				instance.Status,
+				time.Unix(instance.Created, 0).Format(time.RFC3339),
+				strings.Join(processes, ","),
+			)
+		}
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	return instances.Print(os.Stdout)
+}
+
+// shortID returns the first 12 characters of an id, like docker does
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+
+	return id
+}
+
+// isDriversManaged returns true if the drivers are declared in the config file
+func isDriversManaged(drivers []*api.ListDriversResponse_DriverInfo) bool {
+	for _, driver := range drivers {
+		if driver.ConfigStatus != api.ListDriversResponse_UNMANAGED {
+			return true
+		}
+	}
+
+	return false
+}
+
+func driverConfigStatusFmt(driver *api.ListDriversResponse_DriverInfo) string {
+	switch driver.ConfigStatus {
+	case api.ListDriversResponse_OK:
+		return "ok"
+	case api.ListDriversResponse_OUTDATED:
+		return fmt.Sprintf("expected %s", driver.ConfigImage)
+	case api.ListDriversResponse_MISSING:
+		return "missing"
+	case api.ListDriversResponse_UNDECLARED:
+		return "not declared"
+	default:
+		return ""
+	}
+}
+
+// parseDriverInstallCmd represents the parse drivers install command
+type parseDriverInstallCmd struct {
+	Command `name:"install" short-description:"Install a language driver" long-description:"Install a language driver\n\nThis command installs the bblfsh driver for the given language. By default\nthe latest official image, bblfsh/<lang>-driver:latest, is used. A different\ndocker image can be given as a second argument, in the format image[:tag]."`
+
+	Update bool `long:"update" description:"replace the driver if it is already installed"`
+
+	Args struct {
+		Lang  string `positional-arg-name:"lang" required:"yes"`
+		Image string `positional-arg-name:"image:tag"`
+	} `positional-args:"yes"`
+}
+
+func (cmd *parseDriverInstallCmd) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("too many arguments, expected a language and optionally an image")
+	}
+
+	c, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	// Might need to pull bblfshd and the driver images
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	started := logAfterTimeoutWithSpinner("installing driver for "+cmd.Args.Lang, 3*time.Second, 0)
+	_, err = c.InstallDriver(ctx, &api.InstallDriverRequest{
+		Lang:           cmd.Args.Lang,
+		ImageReference: cmd.Args.Image,
+		Update:         cmd.Update,
+	})
+	started()
+
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return fmt.Errorf("driver for %s is already installed, use --update to replace it", cmd.Args.Lang)
+		}
+
+		return humanizef(err, "could not install driver")
+	}
+
+	log.Infof("driver for %s installed", cmd.Args.Lang)
+	return nil
+}
+
+// parseDriverRemoveCmd represents the parse drivers remove command
+type parseDriverRemoveCmd struct {
+	Command `name:"remove" short-description:"Remove a language driver" long-description:"Remove a language driver"`
+
+	Args struct {
+		Lang string `positional-arg-name:"lang" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+func (cmd *parseDriverRemoveCmd) Execute(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("too many arguments, expected only one language")
+	}
+
+	c, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	_, err = c.RemoveDriver(ctx, &api.RemoveDriverRequest{Lang: cmd.Args.Lang})
+	if err != nil {
+		return humanizef(err, "could not remove driver")
 	}
 
-	return t.Print(os.Stdout)
+	log.Infof("driver for %s removed", cmd.Args.Lang)
+	return nil
 }
@@ -1,7 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"time"
+
 	"github.com/pkg/errors"
+	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/cmd/srcd/config"
+	"github.com/src-d/engine/cmd/srcd/daemon"
 	"github.com/src-d/engine/docker"
 )
 
@@ -24,9 +30,7 @@ func humanize(err error) error {
 
 	switch e := err.(type) {
 	case *docker.ContainerBindErr:
-		// TODO(max): instead of placeholders we can actually ask daemon for current config if we would have such API
-		confFile := "$HOME/.srcd/config.yml"
-		workdir := "[workdir]"
+		confFile, workdir := engineLocations()
 
 		errString = "Port " + e.Port + " is already allocated.\n" +
 			"You can define the port to be bound by " + e.Service + " in " + confFile + ", and then run:\n" +
@@ -36,3 +40,36 @@ func humanize(err error) error {
 
 	return errors.New(errString)
 }
+
+// engineLocations returns the path of the config file and the working
+// directory of the running daemon. Placeholders are returned for the ones
+// that can't be found.
+func engineLocations() (confFile, workdir string) {
+	confFile = "$HOME/.srcd/config.yml"
+	workdir = "[workdir]"
+
+	if path, err := config.DefaultPath(); err == nil {
+		confFile = path
+	}
+
+	// the daemon is not started just to get its working directory
+	if running, err := daemon.IsRunning(); err != nil || !running {
+		return
+	}
+
+	client, err := daemon.Client()
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	res, err := client.Status(ctx, &api.StatusRequest{})
+	if err != nil {
+		return
+	}
+
+	workdir = res.Workdir
+	return
+}
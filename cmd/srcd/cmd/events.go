@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	api "github.com/src-d/engine/api"
+	"github.com/src-d/engine/cmd/srcd/daemon"
+)
+
+// eventsCmd represents the events command
+type eventsCmd struct {
+	Command `name:"events" short-description:"Show the lifecycle events of the components" long-description:"Show the lifecycle events of the components: image pulls and their progress, container creations, starts and removals, readiness changes and failures.\n\nThe events are streamed until Ctrl-C is pressed."`
+
+	History bool `long:"history" description:"show the past events kept by the daemon first"`
+	JSON    bool `long:"json" description:"print each event as a JSON document"`
+}
+
+// eventJSON is the JSON output of an event
+type eventJSON struct {
+	ID        uint64      `json:"id"`
+	Type      string      `json:"type"`
+	Time      time.Time   `json:"time"`
+	Component string      `json:"component,omitempty"`
+	Image     string      `json:"image,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Layers    []layerJSON `json:"layers,omitempty"`
+}
+
+// layerJSON is the JSON output of the progress of an image layer
+type layerJSON struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Current int64  `json:"current"`
+	Total   int64  `json:"total"`
+}
+
+func (c *eventsCmd) Execute(args []string) error {
+	client, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	// in case of Ctrl-C or kill, stop watching the events
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, os.Kill)
+	go func() {
+		<-ch
+		cancel()
+	}()
+
+	stream, err := client.WatchEvents(ctx, &api.WatchEventsRequest{History: c.History})
+	if err != nil {
+		return humanizef(err, "could not watch events")
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return humanizef(err, "could not watch events")
+		}
+
+		t := time.Unix(0, e.Time)
+		if !c.JSON {
+			fmt.Printf("%s %s\n", t.Format(time.RFC3339), eventMessage(e))
+			continue
+		}
+
+		var layers []layerJSON
+		for _, l := range e.Layers {
+			layers = append(layers, layerJSON{
+				ID:      l.Id,
+				Status:  l.Status,
+				Current: l.Current,
+				Total:   l.Total,
+			})
+		}
+
+		err = enc.Encode(eventJSON{
+			ID:        e.Id,
+			Type:      strings.ToLower(e.Type.String()),
+			Time:      t,
+			Component: e.Component,
+			Image:     e.Image,
+			Error:     e.Error,
+			Layers:    layers,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// eventMessage returns a human readable description of the event
+func eventMessage(e *api.Event) string {
+	switch e.Type {
+	case api.Event_PULLING:
+		return fmt.Sprintf("pulling image %s", e.Image)
+	case api.Event_PULL_PROGRESS:
+		return pullBar(e.Image, apiLayers(e.Layers), false)
+	case api.Event_PULLED:
+		return fmt.Sprintf("pulled image %s", e.Image)
+	case api.Event_CREATED:
+		return fmt.Sprintf("created container %s (%s)", e.Component, e.Image)
+	case api.Event_STARTED:
+		return fmt.Sprintf("started container %s (%s)", e.Component, e.Image)
+	case api.Event_READY:
+		return fmt.Sprintf("%s is ready", e.Component)
+	case api.Event_NOT_READY:
+		return fmt.Sprintf("%s is not ready", e.Component)
+	case api.Event_FAILED:
+		return fmt.Sprintf("%s failed: %s", e.Component, e.Error)
+	case api.Event_REMOVED:
+		return fmt.Sprintf("removed container %s", e.Component)
+	default:
+		return fmt.Sprintf("unknown event %s for %s", e.Type, e.Component)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(&eventsCmd{})
+}
@@ -22,13 +22,14 @@ import (
 
 	"github.com/src-d/engine/cmd/srcd/config"
 	"github.com/src-d/engine/cmd/srcd/daemon"
+	"github.com/src-d/engine/components"
 
 	"gopkg.in/src-d/go-log.v1"
 )
 
 // initCmd represents the init command
 type initCmd struct {
-	Command `name:"init" short-description:"Starts the daemon or restarts it if already running" long-description:"Starts the daemon or restarts it if already running"`
+	Command `name:"init" short-description:"Starts the daemon or restarts it if already running" long-description:"Starts the daemon of the workspace or restarts it if already running, and selects the workspace for the next commands.\n\nThe daemons of other workspaces keep running. Use --workspace to create a new workspace."`
 
 	Args struct {
 		Workdir string `positional-arg-name:"workdir"`
@@ -75,6 +76,11 @@ func (c *initCmd) Execute(args []string) error {
 	}
 
 	log.Infof("daemon started")
+
+	if err := daemon.UseWorkspace(components.Workspace()); err != nil {
+		return humanizef(err, "could not use workspace")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+
+	api "github.com/src-d/engine/api"
+	"github.com/src-d/engine/cmd/srcd/daemon"
+)
+
+// logsCmd represents the logs command
+type logsCmd struct {
+	Command `name:"logs" short-description:"Show the logs of a component" long-description:"Show the logs of a component container.\n\nThe component must be one of gitbase, gitbase-web, bblfshd, bblfsh-web, daemon, or a component declared in the config file."`
+
+	Follow bool   `short:"f" long:"follow" description:"keep streaming the new logs"`
+	Since  string `long:"since" description:"show the logs since a timestamp (e.g. 2019-05-01T15:04:05Z) or a relative duration (e.g. 10m)"`
+	Tail   int    `long:"tail" default:"-1" default-mask:"all" description:"number of lines to show from the end of the logs"`
+
+	Args struct {
+		Component string `positional-arg-name:"component" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *logsCmd) Execute(args []string) error {
+	client, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	// in case of Ctrl-C or kill, stop following the logs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, os.Kill)
+	go func() {
+		<-ch
+		cancel()
+	}()
+
+	stream, err := client.Logs(ctx, &api.LogsRequest{
+		Component: c.Args.Component,
+		Follow:    c.Follow,
+		Since:     c.Since,
+		Tail:      int32(c.Tail),
+	})
+	if err != nil {
+		return humanizef(err, "could not get logs")
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return humanizef(err, "could not get logs")
+		}
+
+		out := os.Stdout
+		if res.Stream == api.LogsResponse_STDERR {
+			out = os.Stderr
+		}
+
+		if _, err := out.Write(res.Data); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(&logsCmd{})
+}
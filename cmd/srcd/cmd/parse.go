@@ -16,15 +16,20 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	api "github.com/src-d/engine/api"
 	"github.com/src-d/engine/cmd/srcd/daemon"
 
+	"github.com/jessevdk/go-flags"
 	"gopkg.in/src-d/go-cli.v0"
 	"gopkg.in/src-d/go-log.v1"
 )
@@ -36,25 +41,31 @@ type parseCmd struct {
 
 // parseUASTCmd represents the parse uast command
 type parseUASTCmd struct {
-	Command `name:"uast" short-description:"Parse and return the filtered UAST of the given file" long-description:"Parse and return the filtered UAST of the given file\n\nThis command parses the given file, automatically identifying the language\nunless the --lang flag is used. The resulting Universal Abstract Syntax Trees\n(UASTs) are filtered with the given --query XPath expression. By default it\nreturns UAST in semantic mode, it can be changed using --mode flag.\n\nThe remaining nodes are printed to standard output in JSON format."`
+	Command `name:"uast" short-description:"Parse and return the filtered UAST of the given files" long-description:"Parse and return the filtered UAST of the given files\n\nThis command parses the given file, automatically identifying the language\nunless the --lang flag is used. The resulting Universal Abstract Syntax Trees\n(UASTs) are filtered with the given --query XPath expression. By default it\nreturns UAST in semantic mode, it can be changed using --mode flag.\n\nThe remaining nodes are printed to standard output in JSON format.\n\nDirectories are walked recursively, and glob patterns are expanded. In that\ncase files with an unsupported language are skipped, and the result of each\nfile is printed as a JSON document per line, including its path."`
 
-	Lang  string `short:"l" long:"lang" description:"avoid language detection, use this parser"`
-	Query string `short:"q" long:"query" description:"XPath query applied to the parsed UASTs"`
-	Mode  string `short:"m" long:"mode" choice:"semantic" choice:"annotated" choice:"native" default:"semantic" description:"UAST parsing mode"`
+	Lang        string `short:"l" long:"lang" description:"avoid language detection, use this parser"`
+	Query       string `short:"q" long:"query" description:"XPath query applied to the parsed UASTs"`
+	Mode        string `short:"m" long:"mode" choice:"semantic" choice:"annotated" choice:"native" default:"semantic" description:"UAST parsing mode"`
+	Parallelism int    `short:"p" long:"parallelism" default:"4" description:"number of files parsed at the same time, for directories and glob patterns"`
 
 	Args struct {
-		Path string `positional-arg-name:"file-path" required:"yes"`
+		Paths []string `positional-arg-name:"path" required:"yes" description:"file, directory or glob pattern"`
 	} `positional-args:"yes"`
 }
 
 func (cmd *parseUASTCmd) Execute(args []string) error {
 	if len(args) > 0 {
-		return fmt.Errorf("too many arguments, expected only one path")
+		return fmt.Errorf("too many arguments")
 	}
 
-	b, err := ioutil.ReadFile(cmd.Args.Path)
+	if len(cmd.Args.Paths) > 1 || !isRegularFile(cmd.Args.Paths[0]) {
+		return cmd.executeBatch()
+	}
+
+	path := cmd.Args.Paths[0]
+	b, err := ioutil.ReadFile(path)
 	if err != nil {
-		return humanizef(err, "could not read %s", cmd.Args.Path)
+		return humanizef(err, "could not read %s", path)
 	}
 
 	c, err := daemon.Client()
@@ -79,7 +90,7 @@ func (cmd *parseUASTCmd) Execute(args []string) error {
 
 	lang := cmd.Lang
 	if lang == "" {
-		lang, err = parseLang(ctx, c, cmd.Args.Path, b)
+		lang, err = parseLang(ctx, c, path, b)
 		started()
 
 		if err != nil {
@@ -93,7 +104,7 @@ func (cmd *parseUASTCmd) Execute(args []string) error {
 
 	stream, err := c.ParseWithLogs(ctx, &api.ParseRequest{
 		Kind:    api.ParseRequest_UAST,
-		Name:    cmd.Args.Path,
+		Name:    path,
 		Content: b,
 		Lang:    lang,
 		Query:   cmd.Query,
@@ -132,6 +143,195 @@ func (cmd *parseUASTCmd) Execute(args []string) error {
 	}
 }
 
+// parseUASTResult is the JSON document printed for each file parsed from
+// a directory or glob pattern
+type parseUASTResult struct {
+	Path  string            `json:"path"`
+	Lang  string            `json:"lang,omitempty"`
+	UAST  []json.RawMessage `json:"uast,omitempty"`
+	Error string            `json:"error,omitempty"`
+}
+
+func (cmd *parseUASTCmd) executeBatch() error {
+	paths, err := expandPaths(cmd.Args.Paths)
+	if err != nil {
+		return err
+	}
+
+	mode, err := parseModeArg(cmd.Mode)
+	if err != nil {
+		return err
+	}
+
+	c, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resp, err := c.ListDrivers(ctx, &api.ListDriversRequest{})
+	if err != nil {
+		return humanizef(err, "could not list drivers")
+	}
+
+	supported := make(map[string]bool)
+	for _, driver := range resp.Drivers {
+		supported[driver.Lang] = true
+	}
+
+	stream, err := c.ParseBatch(ctx)
+	if err != nil {
+		return humanizef(err, "could not start parsing")
+	}
+
+	var parsed, failed, skipped int32
+	stop := logAfterTimeoutWithProgress("parsing files", 3*time.Second, func() string {
+		return fmt.Sprintf("(%d parsed, %d failed, %d skipped)",
+			atomic.LoadInt32(&parsed),
+			atomic.LoadInt32(&failed),
+			atomic.LoadInt32(&skipped))
+	})
+	defer stop()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				recvErr <- nil
+				return
+			}
+
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			out := parseUASTResult{Path: res.Name, Error: res.Error}
+			if res.Error != "" {
+				atomic.AddInt32(&failed, 1)
+				log.Debugf("could not parse %s: %s", res.Name, res.Error)
+			} else {
+				atomic.AddInt32(&parsed, 1)
+				out.Lang = res.Result.Lang
+				for _, node := range res.Result.Uast {
+					out.UAST = append(out.UAST, json.RawMessage(node))
+				}
+			}
+
+			if err := enc.Encode(out); err != nil {
+				recvErr <- err
+				return
+			}
+		}
+	}()
+
+	first := true
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return humanizef(err, "could not read %s", path)
+		}
+
+		// the language is detected locally, to avoid a request for each file
+		lang := api.DriverLang(cmd.Lang)
+		if lang == "" {
+			lang = api.DetectLanguage(path, b)
+		}
+
+		if !supported[lang] {
+			atomic.AddInt32(&skipped, 1)
+			log.Debugf("skipping %s, unsupported language '%s'", path, lang)
+			continue
+		}
+
+		req := &api.ParseBatchRequest{File: &api.ParseRequest{
+			Kind:    api.ParseRequest_UAST,
+			Name:    path,
+			Content: b,
+			Lang:    lang,
+			Query:   cmd.Query,
+			Mode:    mode,
+		}}
+		if first {
+			req.Parallelism = int32(cmd.Parallelism)
+			first = false
+		}
+
+		if err := stream.Send(req); err != nil {
+			// the actual error is returned by Recv
+			break
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return humanizef(err, "could not stream")
+	}
+
+	if err := <-recvErr; err != nil {
+		return humanizef(err, "could not stream")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files could not be parsed", failed, failed+parsed)
+	}
+
+	return nil
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// expandPaths returns all the regular files found in the given paths,
+// expanding glob patterns and walking directories recursively.
+// Hidden directories are skipped.
+func expandPaths(patterns []string) ([]string, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %s", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files found for %s", pattern)
+		}
+
+		for _, match := range matches {
+			err := filepath.Walk(match, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+
+				if info.IsDir() {
+					if path != match && strings.HasPrefix(info.Name(), ".") {
+						return filepath.SkipDir
+					}
+
+					return nil
+				}
+
+				if info.Mode().IsRegular() {
+					paths = append(paths, path)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, humanizef(err, "could not read %s", match)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
 // parseLangCmd represents the parse lang command
 type parseLangCmd struct {
 	Command `name:"lang" short-description:"Identify the language of the given file" long-description:"Identify the language of the given file"`
@@ -170,7 +370,12 @@ func init() {
 	c := rootCmd.AddCommand(&parseCmd{})
 	c.AddCommand(&parseUASTCmd{})
 	c.AddCommand(&parseLangCmd{})
-	c.AddCommand(&parseDriverCmd{})
+	d := c.AddCommand(&parseDriverCmd{}, func(c *flags.Command) {
+		// list drivers when no subcommand is given
+		c.SubcommandsOptional = true
+	})
+	d.AddCommand(&parseDriverInstallCmd{})
+	d.AddCommand(&parseDriverRemoveCmd{})
 }
 
 func parseModeArg(mode string) (api.ParseRequest_UastMode, error) {
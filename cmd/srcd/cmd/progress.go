@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	units "github.com/docker/go-units"
+	api "github.com/src-d/engine/api"
+	"github.com/src-d/engine/docker"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+// pullBarWidth is the number of characters inside the brackets of a bar
+const pullBarWidth = 30
+
+// pullBars renders the progress of the image pulls as one progress bar per
+// image, below the rest of the output. It must only be used when the output
+// is a terminal.
+type pullBars struct {
+	mu     sync.Mutex
+	w      io.Writer
+	images []string
+	layers map[string][]docker.LayerProgress
+	done   map[string]bool
+	// lines is the number of bars currently drawn
+	lines int
+}
+
+func newPullBars(w io.Writer) *pullBars {
+	return &pullBars{
+		w:      w,
+		layers: make(map[string][]docker.LayerProgress),
+		done:   make(map[string]bool),
+	}
+}
+
+// showPullBars returns whether the progress bars can be drawn for an output
+func showPullBars(isTerminal bool) bool {
+	return isTerminal &&
+		log.DefaultFactory.Format == log.TextFormat &&
+		(log.DefaultFactory.Level == log.InfoLevel ||
+			log.DefaultFactory.Level == log.DebugLevel)
+}
+
+// Update redraws the bars with the new progress of an image pull
+func (b *pullBars) Update(image string, layers []docker.LayerProgress) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.clear()
+	b.add(image)
+	b.layers[image] = layers
+	b.draw()
+}
+
+// Done redraws the bars marking an image pull as finished
+func (b *pullBars) Done(image string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.clear()
+	b.add(image)
+	b.done[image] = true
+	b.draw()
+}
+
+// Println calls f to print some lines above the bars
+func (b *pullBars) Println(f func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.clear()
+	f()
+	b.draw()
+}
+
+func (b *pullBars) add(image string) {
+	if _, ok := b.layers[image]; !ok {
+		b.images = append(b.images, image)
+		b.layers[image] = nil
+	}
+}
+
+// clear removes the bars drawn, leaving the cursor where the first one was
+func (b *pullBars) clear() {
+	for i := 0; i < b.lines; i++ {
+		fmt.Fprint(b.w, "\033[A\033[2K")
+	}
+
+	fmt.Fprint(b.w, "\r")
+	b.lines = 0
+}
+
+func (b *pullBars) draw() {
+	for _, image := range b.images {
+		fmt.Fprintln(b.w, pullBar(image, b.layers[image], b.done[image]))
+	}
+
+	b.lines = len(b.images)
+}
+
+// pullBar returns the progress bar of an image pull. The download and the
+// extraction of the layers weigh the same in the bar.
+func pullBar(image string, layers []docker.LayerProgress, done bool) string {
+	var total, downloaded, extracted int64
+	var ready int
+	for _, l := range layers {
+		total += l.Total
+		downloaded += l.Downloaded()
+		extracted += l.Extracted()
+		if l.Done() {
+			ready++
+		}
+	}
+
+	var ratio float64
+	switch {
+	case done:
+		ratio = 1
+	case total > 0:
+		ratio = float64(downloaded+extracted) / float64(2*total)
+	}
+
+	filled := int(ratio * pullBarWidth)
+	bar := strings.Repeat("=", filled)
+	if filled < pullBarWidth {
+		bar += ">" + strings.Repeat(" ", pullBarWidth-filled-1)
+	}
+
+	if done {
+		return fmt.Sprintf("%s [%s] done", image, bar)
+	}
+
+	return fmt.Sprintf("%s [%s] %3d%% %s/%s, %d/%d layers",
+		image, bar, int(ratio*100),
+		units.HumanSize(float64(downloaded)), units.HumanSize(float64(total)),
+		ready, len(layers))
+}
+
+// apiLayers converts the layers of a PULL_PROGRESS event
+func apiLayers(layers []*api.Event_Layer) []docker.LayerProgress {
+	result := make([]docker.LayerProgress, len(layers))
+	for i, l := range layers {
+		result[i] = docker.LayerProgress{
+			ID:      l.Id,
+			Status:  l.Status,
+			Current: l.Current,
+			Total:   l.Total,
+		}
+	}
+
+	return result
+}
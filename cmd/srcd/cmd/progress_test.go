@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullBar(t *testing.T) {
+	require := require.New(t)
+
+	layers := []docker.LayerProgress{
+		{ID: "a1", Status: "Pull complete", Total: 100},
+		{ID: "b2", Status: "Downloading", Current: 50, Total: 100},
+	}
+
+	bar := pullBar("srcd/gitbase:v0.19.0", layers, false)
+	require.Equal("srcd/gitbase:v0.19.0 [==================>           ]  62% 150B/200B, 1/2 layers", bar)
+
+	bar = pullBar("srcd/gitbase:v0.19.0", layers, true)
+	require.Equal("srcd/gitbase:v0.19.0 [==============================] done", bar)
+
+	bar = pullBar("srcd/gitbase:v0.19.0", nil, false)
+	require.Equal("srcd/gitbase:v0.19.0 [>                             ]   0% 0B/0B, 0/0 layers", bar)
+}
+
+func TestPullBarsRedraw(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	bars := newPullBars(&buf)
+	bars.Update("a:1", nil)
+	bars.Update("b:2", nil)
+	bars.Println(func() { buf.WriteString("message\n") })
+	bars.Done("a:1")
+
+	// the bars are removed before printing the message and redrawing them
+	require.Equal(2, strings.Count(buf.String(), "\033[A\033[2K\033[A\033[2K"))
+	require.True(strings.HasSuffix(buf.String(), "message\n"+
+		"a:1 [>                             ]   0% 0B/0B, 0/0 layers\n"+
+		"b:2 [>                             ]   0% 0B/0B, 0/0 layers\n"+
+		"\033[A\033[2K\033[A\033[2K\r"+
+		"a:1 [==============================] done\n"+
+		"b:2 [>                             ]   0% 0B/0B, 0/0 layers\n"))
+}
@@ -13,6 +13,12 @@ type pruneCmd struct {
 }
 
 func (c *pruneCmd) Execute(args []string) error {
+	if c.WithImages {
+		if _, err := loadConfigComponents(); err != nil {
+			return err
+		}
+	}
+
 	if err := components.Prune(c.WithImages); err != nil {
 		return humanizef(err, "could not prune components")
 	}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/src-d/engine/api"
+	"github.com/src-d/engine/cmd/srcd/daemon"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxQueryLen is the maximum length of the queries shown by sql ps
+const maxQueryLen = 60
+
+// sqlPsCmd represents the sql ps command
+type sqlPsCmd struct {
+	Command `name:"ps" short-description:"List the running queries" long-description:"List the queries running in gitbase, from any client.\n\nThe ID column can be used to kill a query with srcd sql kill."`
+}
+
+func (cmd *sqlPsCmd) Execute(args []string) error {
+	c, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := c.ListQueries(ctx, &api.ListQueriesRequest{})
+	if err != nil {
+		return humanizef(err, "could not list queries")
+	}
+
+	t := NewTable("%v", "%s", "%s", "%s", "%s", "%s")
+	t.Header("ID", "USER", "HOST", "TIME", "STATE", "QUERY")
+	for _, q := range res.Queries {
+		t.Row(q.Id, q.User, q.Host,
+			time.Duration(q.Time)*time.Second,
+			q.State, queryFmt(q.Query))
+	}
+
+	return t.Print(os.Stdout)
+}
+
+func queryFmt(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if len(query) > maxQueryLen {
+		return query[:maxQueryLen-3] + "..."
+	}
+
+	return query
+}
+
+// sqlKillCmd represents the sql kill command
+type sqlKillCmd struct {
+	Command `name:"kill" short-description:"Kill a running query" long-description:"Kill a query running in gitbase, given its ID as shown by srcd sql ps"`
+
+	Args struct {
+		ID string `positional-arg-name:"id" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+func (cmd *sqlKillCmd) Execute(args []string) error {
+	id, err := strconv.ParseInt(cmd.Args.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid query id %s", cmd.Args.ID)
+	}
+
+	c, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = c.KillQuery(ctx, &api.KillQueryRequest{Id: id})
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("there is no running query with id %d", id)
+	case codes.FailedPrecondition:
+		return fmt.Errorf("there is no running query with id %d, %s",
+			id, status.Convert(err).Message())
+	}
+	if err != nil {
+		return humanizef(err, "could not kill query %d", id)
+	}
+
+	fmt.Printf("query %d killed\n", id)
+	return nil
+}
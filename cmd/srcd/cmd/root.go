@@ -15,11 +15,11 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
-	"regexp"
+	"io"
 	"time"
 
+	api "github.com/src-d/engine/api"
 	"github.com/src-d/engine/cmd/srcd/daemon"
 
 	"gopkg.in/src-d/go-cli.v0"
@@ -36,7 +36,27 @@ type Command struct {
 	cli.PlainCommand
 	cli.LogOptions `group:"Log Options"`
 
-	Config string `long:"config" description:"config file (default: $HOME/.srcd/config.yml)"`
+	Config    string `long:"config" description:"config file (default: $HOME/.srcd/config.yml)"`
+	Context   string `long:"context" env:"SRCD_CONTEXT" description:"engine context to use (default: the one selected with srcd context use)"`
+	Workspace string `long:"workspace" env:"SRCD_WORKSPACE" description:"workspace to use (default: the one selected with srcd workspace use)"`
+}
+
+// Init implements cli.Initializer. It loads the engine context and the
+// workspace the command works with
+func (c Command) Init(a *cli.App) error {
+	if err := c.LogOptions.Init(a); err != nil {
+		return err
+	}
+
+	if err := daemon.LoadContext(c.Context); err != nil {
+		return humanizef(err, "could not load the engine context")
+	}
+
+	if err := daemon.LoadWorkspace(c.Workspace); err != nil {
+		return humanizef(err, "could not load the workspace")
+	}
+
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -46,8 +66,6 @@ func Execute() {
 	rootCmd.RunMain()
 }
 
-var logMsgRegex = regexp.MustCompile(`.*msg="(.+)"`)
-
 func logAfterTimeout(msg string, timeout time.Duration) func() {
 	d := newDefered(timeout, msg, nil, false, 0)
 	return d.Print()
@@ -58,53 +76,87 @@ func logAfterTimeoutWithSpinner(msg string, timeout time.Duration, spinnerInterv
 	return d.Print()
 }
 
-func logAfterTimeoutWithServerLogs(msg string, timeout time.Duration) func() {
-	d := newDefered(timeout, msg, readDaemonLogs, false, 0)
+func logAfterTimeoutWithProgress(msg string, timeout time.Duration, progressFn func() string) func() {
+	d := newDefered(timeout, msg, nil, true, 0)
+	d.ProgressFn = progressFn
 	return d.Print()
 }
 
-func readDaemonLogs(stop <-chan bool) <-chan string {
-	logs, err := daemon.GetLogs()
+// logAfterTimeoutWithServerEvents prints the events of the daemon after the
+// message. The progress of the image pulls is shown as progress bars when
+// the output is a terminal.
+func logAfterTimeoutWithServerEvents(msg string, timeout time.Duration) func() {
+	d := newDefered(timeout, msg, nil, false, 0)
+	if showPullBars(d.isTerminal) {
+		d.Bars = newPullBars(d.logWriter)
+	}
+
+	d.InputFn = func(stop <-chan bool) <-chan string {
+		return readDaemonEvents(stop, d.Bars)
+	}
+
+	return d.Print()
+}
+
+// readDaemonEvents sends the description of the new events of the daemon,
+// until stop is closed. The pull progress events update bars, if it is not
+// nil, instead of being sent.
+func readDaemonEvents(stop <-chan bool, bars *pullBars) <-chan string {
+	// the caller reads until the channel is closed
+	closed := make(chan string)
+	close(closed)
+
+	client, err := daemon.Client()
 	if err != nil {
-		log.Errorf(err, "could not get logs from server container")
-		return nil
+		log.Errorf(err, "could not get daemon client")
+		return closed
 	}
 
-	ch := make(chan string)
-	go func() {
-		defer logs.Close()
-		scanner := bufio.NewScanner(logs)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.WatchEvents(ctx, &api.WatchEventsRequest{})
+	if err != nil {
+		cancel()
+		log.Errorf(err, "could not watch daemon events")
+		return closed
+	}
 
-		c := make(chan bool)
-		scan := func() {
-			c <- scanner.Scan()
-		}
+	go func() {
+		<-stop
+		cancel()
+	}()
 
-		go scan()
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
 		for {
-			select {
-			case <-stop:
-				close(ch)
-				return
-			case more := <-c:
-				if !more {
-					close(ch)
-					if err := scanner.Err(); err != nil && err != context.Canceled {
-						log.Errorf(err, "can't read logs from server")
-					}
-
-					return
+			e, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					log.Errorf(err, "can't read events from server")
 				}
 
-				match := logMsgRegex.FindStringSubmatch(scanner.Text())
-				if len(match) == 2 {
-					ch <- match[1]
+				return
+			}
+
+			if e.Type == api.Event_PULL_PROGRESS {
+				if bars != nil {
+					bars.Update(e.Image, apiLayers(e.Layers))
 				}
 
-				go scan()
+				continue
+			}
+
+			if e.Type == api.Event_PULLED && bars != nil {
+				bars.Done(e.Image)
+				continue
 			}
-		}
 
+			select {
+			case ch <- eventMessage(e):
+			case <-stop:
+				return
+			}
+		}
 	}()
 
 	return ch
@@ -15,14 +15,17 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/signal"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/src-d/engine/api"
 	"github.com/src-d/engine/cmd/srcd/daemon"
@@ -32,49 +35,54 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/term"
+	"github.com/jessevdk/go-flags"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/src-d/go-log.v1"
 )
 
-// sqlCmd represents the sql command
-
+// sqlCmd represents the sql command. The query is not declared as a
+// positional argument, otherwise it would take precedence over the subcommands
 type sqlCmd struct {
-	Command `name:"sql" short-description:"Run a SQL query over the analyzed repositories" long-description:"Run a SQL query over the analyzed repositories"`
+	Command `name:"sql" short-description:"Run a SQL query over the analyzed repositories" long-description:"Run a SQL query over the analyzed repositories\n\nThe query is given as the only argument, or piped to the standard input.\nSeveral queries can be piped, separated by semicolons. The queries are run\nby the daemon, and they are killed if the command is interrupted or if they\ntake longer than --timeout.\n\nIf there is no query, an interactive SQL shell is started."`
 
-	Args struct {
-		Query string `positional-arg-name:"query"`
-	} `positional-args:"yes"`
+	Timeout time.Duration `long:"timeout" description:"maximum execution time of each query, like 30s or 5m, no timeout by default. Ignored by the interactive shell"`
 }
 
 func (c *sqlCmd) Execute(args []string) error {
-	if len(args) > 0 {
+	if len(args) > 1 {
 		return fmt.Errorf("too many arguments, expected only one query or nothing")
 	}
 
+	if c.Timeout < 0 {
+		return fmt.Errorf("invalid timeout %s", c.Timeout)
+	}
+
 	client, err := daemon.Client()
 	if err != nil {
 		return humanizef(err, "could not get daemon client")
 	}
 
-	if err := startGitbaseWithClient(client); err != nil {
-		return err
-	}
+	// in case of Ctrl-C or kill, cancel the pending calls to the daemon
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	connReady := logAfterTimeoutWithSpinner("waiting for gitbase to be ready", 5*time.Second, 0)
-	err = ensureConnReady(client)
-	connReady()
-	if err != nil {
-		return humanizef(err, "could not connect to gitbase")
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, os.Kill)
+	go func() {
+		<-ch
+		cancel()
+	}()
+
+	if err := startGitbaseWithClient(ctx, client); err != nil {
+		return err
 	}
 
 	var query string
-	if c.Args.Query != "" {
-		query = strings.TrimSpace(c.Args.Query)
+	if len(args) == 1 && args[0] != "" {
+		query = strings.TrimSpace(args[0])
 	} else {
 		// Support piping
-		// TODO(@smacker): not the most optimal solution
-		// it would read all input into memory first and only then send to gitbase
-		// it must be possible to pipe and running mysql-cli with -B flag
-		// but it would change current client behaviour
 		fi, _ := os.Stdin.Stat()
 		if (fi.Mode() & os.ModeCharDevice) == 0 {
 			b, err := ioutil.ReadAll(os.Stdin)
@@ -86,104 +94,179 @@ func (c *sqlCmd) Execute(args []string) error {
 		}
 	}
 
-	resp, exit, err := runMysqlCli(context.Background(), query)
-	if err != nil {
-		return humanizef(err, "could not run mysql client")
+	if query != "" {
+		for _, q := range splitQueries(query) {
+			if err := runQuery(ctx, client, q, c.Timeout); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}
-	defer resp.Close()
-	defer stopMysqlClient()
 
-	// in case of Ctrl-C or kill defer wouldn't work
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt, os.Kill)
-	go func() {
-		<-ch
-		stopMysqlClient()
-	}()
+	return runMysqlShell(ctx, client)
+}
 
-	if query != "" {
-		if _, err = io.Copy(os.Stdout, resp.Reader); err != nil {
-			return err
+// runQuery runs the query through the daemon and prints its result as a
+// table. The query is killed by the daemon if the context is cancelled.
+func runQuery(ctx context.Context, client api.EngineClient, query string, timeout time.Duration) error {
+	stream, err := client.SQL(ctx, &api.SQLRequest{
+		Query:     query,
+		TimeoutMs: int64(timeout / time.Millisecond),
+	})
+	if err != nil {
+		return sqlErr(err)
+	}
+
+	var columns []*api.SQLResponse_Column
+	var rows []*api.SQLResponse_Row
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return sqlErr(err)
 		}
 
-		cd := int(<-exit)
-		if cd != 0 {
-			return fmt.Errorf("MySQL exited with status %d", cd)
+		if columns == nil {
+			columns = sqlColumns(res)
+			continue
 		}
 
-		return nil
+		rows = append(rows, res.Row)
 	}
 
-	return attachStdio(resp)
+	return printSQLTable(os.Stdout, columns, rows)
 }
 
-func ensureConnReady(client api.EngineClient) error {
-	ctx := context.Background()
-
-	done := make(chan error)
-	globalTimeout := 5 * time.Minute
-	go func(ctx context.Context) {
-		queryTimeout := 1 * time.Second
-		sleep := 1 * time.Second
-		for {
-			err := pingDB(ctx, client, queryTimeout)
-			if err == nil {
-				break
-			}
+// sqlColumns returns the columns of the first response of a query. The
+// servers that do not send a header only send the names of the columns.
+func sqlColumns(res *api.SQLResponse) []*api.SQLResponse_Column {
+	if res.Header != nil {
+		return res.Header.Columns
+	}
 
-			time.Sleep(sleep)
-		}
+	columns := make([]*api.SQLResponse_Column, len(res.Row.GetCell()))
+	for i, name := range res.Row.GetCell() {
+		columns[i] = &api.SQLResponse_Column{Name: string(name), Nullable: true}
+	}
 
-		done <- nil
-	}(ctx)
+	return columns
+}
 
-	ctx, cancel := context.WithTimeout(ctx, globalTimeout)
-	defer cancel()
+// sqlErr returns the error of a query without the details of the gRPC call
+func sqlErr(err error) error {
+	switch status.Code(err) {
+	case codes.Canceled:
+		return fmt.Errorf("query cancelled")
+	case codes.DeadlineExceeded:
+		return fmt.Errorf("query timeout exceeded")
+	case codes.Unknown, codes.ResourceExhausted:
+		return fmt.Errorf("%s", status.Convert(err).Message())
+	default:
+		return humanizef(err, "could not run query")
+	}
+}
 
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("global timeout of %v exceeded", globalTimeout)
-	case <-done:
-		return nil
+// runMysqlShell starts an interactive MySQL client connected to gitbase. The
+// queries it is running are killed when it exits or the context is cancelled.
+func runMysqlShell(ctx context.Context, client api.EngineClient) error {
+	// the mysql client image can be replaced in the config file
+	conf, err := loadConfigComponents()
+	if err != nil {
+		return err
 	}
+
+	if err := docker.EnsureInstalled(components.MysqlCli.Image, components.MysqlCli.Version); err != nil {
+		return humanizef(err, "could not install mysql client")
+	}
+
+	workdirHash, err := daemon.WorkdirHash()
+	if err != nil {
+		return humanizef(err, "could not read the daemon state")
+	}
+
+	opts := conf.Components.MysqlCli.Options()
+	if workdirHash != "" {
+		opts = append(opts, docker.WithLabel(docker.LabelWorkdir, workdirHash))
+	}
+
+	resp, _, err := runMysqlCli(ctx, opts...)
+	if err != nil {
+		return humanizef(err, "could not run mysql client")
+	}
+	defer resp.Close()
+
+	ip, err := docker.IPAddress(components.MysqlCli.Name)
+	if err != nil {
+		log.Warningf("could not get the address of the mysql client, "+
+			"its queries won't be killed on exit: %s", err)
+	}
+
+	// in case of Ctrl-C or kill defer wouldn't work
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+
+		if ip != "" {
+			killClientQueries(client, ip)
+		}
+
+		stopMysqlClient()
+	}()
+
+	err = attachStdio(resp)
+	close(done)
+	<-stopped
+	return err
 }
 
-func pingDB(ctx context.Context, client api.EngineClient, queryTimeoutSeconds time.Duration) error {
-	ctx, cancel := context.WithTimeout(ctx, queryTimeoutSeconds)
+// killClientQueries kills the queries running in gitbase sent from the given
+// client address
+func killClientQueries(client api.EngineClient, ip string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	done := make(chan error)
-	go func(ctx context.Context, done chan error) {
-		stream, err := client.SQL(ctx, &api.SQLRequest{Query: "SELECT 1"})
-		if err != nil {
-			done <- err
-		}
+	res, err := client.ListQueries(ctx, &api.ListQueriesRequest{})
+	if err != nil {
+		log.Warningf("could not list the running queries: %s", err)
+		return
+	}
 
-		_, err = stream.Recv()
+	for _, q := range res.Queries {
+		host, _, err := net.SplitHostPort(q.Host)
 		if err != nil {
-			done <- err
+			host = q.Host
 		}
 
-		done <- nil
-	}(ctx, done)
+		if host != ip || q.Query == "" {
+			continue
+		}
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case err := <-done:
-		return err
+		log.Debugf("killing query %d of the mysql client", q.Id)
+		_, err = client.KillQuery(ctx, &api.KillQueryRequest{Id: q.Id})
+		if err != nil && status.Code(err) != codes.NotFound {
+			log.Warningf("could not kill query %d: %s", q.Id, err)
+		}
 	}
 }
 
-func startGitbaseWithClient(client api.EngineClient) error {
-	started := logAfterTimeoutWithServerLogs("this is taking a while, "+
+func startGitbaseWithClient(ctx context.Context, client api.EngineClient) error {
+	started := logAfterTimeoutWithServerEvents("this is taking a while, "+
 		"if this is the first time you launch sql client, "+
 		"it might take a few more minutes while we install all the required images",
 		5*time.Second)
 	defer started()
 
 	// Download & run dependencies
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 	_, err := client.StartComponent(ctx, &api.StartComponentRequest{
 		Name: components.Gitbase.Name,
@@ -192,27 +275,18 @@ func startGitbaseWithClient(client api.EngineClient) error {
 		return humanizef(err, "could not start gitbase")
 	}
 
-	if err := docker.EnsureInstalled(components.MysqlCli.Image, components.MysqlCli.Version); err != nil {
-		return humanizef(err, "could not install mysql client")
-	}
-
 	return nil
 }
 
-func runMysqlCli(ctx context.Context, query string, opts ...docker.ConfigOption) (*types.HijackedResponse, chan int64, error) {
-	cmd := []string{"mysql", "-h", components.Gitbase.Name}
-	if query != "" {
-		cmd = append(cmd, "-e", query)
-	}
-
+func runMysqlCli(ctx context.Context, opts ...docker.ConfigOption) (*types.HijackedResponse, chan int64, error) {
 	config := &container.Config{
 		Image: components.MysqlCli.ImageWithVersion(),
-		Cmd:   cmd,
+		Cmd:   []string{"mysql", "-h", components.Gitbase.Name},
 	}
 	host := &container.HostConfig{}
 	docker.ApplyOptions(config, host, opts...)
 
-	return docker.Attach(context.Background(), config, host, components.MysqlCli.Name)
+	return docker.Attach(ctx, config, host, components.MysqlCli.Name)
 }
 
 func attachStdio(resp *types.HijackedResponse) (err error) {
@@ -269,6 +343,149 @@ func stopMysqlClient() {
 	}
 }
 
+// splitQueries splits the input into the queries separated by semicolons,
+// ignoring the ones inside quotes and comments. The queries with only
+// comments or spaces are skipped.
+func splitQueries(input string) []string {
+	var queries []string
+	var start int
+	// empty is true while the current query has only comments or spaces
+	empty := true
+	add := func(end int) {
+		if !empty {
+			queries = append(queries, strings.TrimSpace(input[start:end]))
+		}
+
+		start = end + 1
+		empty = true
+	}
+
+	for i := 0; i < len(input); i++ {
+		switch ch := input[i]; {
+		case ch == ';':
+			add(i)
+		case ch == '\'' || ch == '"' || ch == '`':
+			empty = false
+			for i++; i < len(input) && input[i] != ch; i++ {
+				if input[i] == '\\' && ch != '`' {
+					i++
+				}
+			}
+		case ch == '#' || isLineComment(input[i:]):
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(input[i:], "/*"):
+			end := strings.Index(input[i+2:], "*/")
+			if end < 0 {
+				i = len(input)
+			} else {
+				i += end + 3
+			}
+		case ch != ' ' && ch != '\t' && ch != '\n' && ch != '\r':
+			empty = false
+		}
+	}
+
+	add(len(input))
+	return queries
+}
+
+// isLineComment returns whether the input starts with a -- comment, that
+// must be followed by a space or the end of the input
+func isLineComment(input string) bool {
+	if !strings.HasPrefix(input, "--") {
+		return false
+	}
+
+	return len(input) == 2 || strings.ContainsAny(input[2:3], " \t\r\n")
+}
+
+// printSQLTable prints the result of a query as a table, like the MySQL
+// client does. NULL values are printed as NULL, and the numeric columns are
+// aligned to the right. Nothing is printed if the result has no columns, like
+// the result of CREATE INDEX.
+func printSQLTable(w io.Writer, columns []*api.SQLResponse_Column, rows []*api.SQLResponse_Row) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = utf8.RuneCountInString(c.Name)
+	}
+
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(columns))
+		for j := range columns {
+			cell := "NULL"
+			if j < len(row.Cell) && !row.IsNull(j) {
+				cell = string(row.Cell[j])
+			}
+
+			cells[i][j] = cell
+			if n := utf8.RuneCountInString(cell); n > widths[j] {
+				widths[j] = n
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	sep := func() {
+		buf.WriteString("+")
+		for _, width := range widths {
+			buf.WriteString(strings.Repeat("-", width+2) + "+")
+		}
+		buf.WriteString("\n")
+	}
+	line := func(values []string, alignRight func(i int) bool) {
+		buf.WriteString("|")
+		for i, v := range values {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(v))
+			if alignRight(i) {
+				buf.WriteString(" " + pad + v + " |")
+			} else {
+				buf.WriteString(" " + v + pad + " |")
+			}
+		}
+		buf.WriteString("\n")
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+
+	sep()
+	line(names, func(int) bool { return false })
+	sep()
+	for _, row := range cells {
+		line(row, func(i int) bool { return isNumericType(columns[i].Type) })
+	}
+	sep()
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// isNumericType returns whether a MySQL type name is a number type
+func isNumericType(typ string) bool {
+	typ = strings.ToUpper(typ)
+	for _, t := range []string{"INT", "DECIMAL", "FLOAT", "DOUBLE"} {
+		if strings.Contains(typ, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func init() {
-	rootCmd.AddCommand(&sqlCmd{})
+	c := rootCmd.AddCommand(&sqlCmd{}, func(c *flags.Command) {
+		// run the query when no subcommand is given
+		c.SubcommandsOptional = true
+	})
+	c.AddCommand(&sqlPsCmd{})
+	c.AddCommand(&sqlKillCmd{})
 }
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/src-d/engine/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitQueries(t *testing.T) {
+	require := require.New(t)
+
+	cases := []struct {
+		input   string
+		queries []string
+	}{
+		{"show tables", []string{"show tables"}},
+		{" show tables ; ", []string{"show tables"}},
+		{"show tables;\ndescribe table repositories;\n",
+			[]string{"show tables", "describe table repositories"}},
+		{"select ';', \"a;b\", `c;d` from t; select 'it\\'s;'",
+			[]string{"select ';', \"a;b\", `c;d` from t", "select 'it\\'s;'"}},
+		{"/* comment; */ show tables;", []string{"/* comment; */ show tables"}},
+		{"select 1; -- last; query\n", []string{"select 1"}},
+		{"select 1 # comment;\n; # only a comment;", []string{"select 1 # comment;"}},
+		{"select 2--1", []string{"select 2--1"}},
+		{";;\n", nil},
+	}
+
+	for _, c := range cases {
+		require.Equal(c.queries, splitQueries(c.input), c.input)
+	}
+}
+
+func TestPrintSQLTable(t *testing.T) {
+	require := require.New(t)
+
+	columns := []*api.SQLResponse_Column{
+		{Name: "repository_id", Type: "TEXT"},
+		{Name: "files", Type: "BIGINT", Nullable: true},
+		{Name: "comment", Type: "TEXT", Nullable: true},
+	}
+	rows := []*api.SQLResponse_Row{
+		{
+			Cell: [][]byte{[]byte("engine"), []byte("1200"), []byte("")},
+			Null: []bool{false, false, false},
+		},
+		{
+			Cell: [][]byte{[]byte("go-git"), nil, nil},
+			Null: []bool{false, true, true},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(printSQLTable(&buf, columns, rows))
+	require.Equal(`+---------------+-------+---------+
+| repository_id | files | comment |
++---------------+-------+---------+
+| engine        |  1200 |         |
+| go-git        |  NULL | NULL    |
++---------------+-------+---------+
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(printSQLTable(&buf, columns[:1], nil))
+	require.Equal(`+---------------+
+| repository_id |
++---------------+
++---------------+
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(printSQLTable(&buf, nil, nil))
+	require.Empty(buf.String())
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	api "github.com/src-d/engine/api"
+	"github.com/src-d/engine/cmd/srcd/daemon"
+	"github.com/src-d/engine/docker"
+)
+
+// statusCmd represents the status command
+type statusCmd struct {
+	Command `name:"status" short-description:"Show the state of the engine" long-description:"Show the workspace, working directory, host OS and state of each component of the engine.\n\nWith --json the effective config of the daemon is included too."`
+
+	JSON bool `long:"json" description:"print the status as JSON"`
+}
+
+// componentStatus is the JSON output of a component status
+type componentStatus struct {
+	Name   string       `json:"name"`
+	Image  string       `json:"image"`
+	State  string       `json:"state"`
+	Ready  bool         `json:"ready"`
+	Ports  []portStatus `json:"ports"`
+	Limits limitsStatus `json:"limits"`
+}
+
+type portStatus struct {
+	HostIP      string `json:"host_ip"`
+	PublicPort  int32  `json:"public_port"`
+	PrivatePort int32  `json:"private_port"`
+}
+
+// limitsStatus is the JSON output of the resource limits of a component,
+// 0 means no limit
+type limitsStatus struct {
+	CPUs       float64 `json:"cpus"`
+	Memory     int64   `json:"memory"`
+	MemorySwap int64   `json:"memory_swap"`
+	PidsLimit  int64   `json:"pids_limit"`
+}
+
+// engineStatus is the JSON output of srcd status
+type engineStatus struct {
+	Version    string            `json:"version"`
+	Workspace  string            `json:"workspace"`
+	Workdir    string            `json:"workdir"`
+	HostOS     string            `json:"host_os"`
+	Config     string            `json:"config"`
+	Components []componentStatus `json:"components"`
+}
+
+func (c *statusCmd) Execute(args []string) error {
+	client, err := daemon.Client()
+	if err != nil {
+		return humanizef(err, "could not get daemon client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := client.Status(ctx, &api.StatusRequest{})
+	if err != nil {
+		return humanizef(err, "could not get the engine status")
+	}
+
+	if c.JSON {
+		return printStatusJSON(res)
+	}
+
+	fmt.Printf("daemon version: %s\n", res.Version)
+	fmt.Printf("workspace: %s\n", res.Workspace)
+	fmt.Printf("workdir: %s\n", res.Workdir)
+	fmt.Printf("host OS: %s\n\n", res.HostOs)
+
+	t := NewTable("%s", "%s", "%s", "%s", "%s", "%s")
+	t.Header("NAME", "IMAGE", "STATE", "READY", "PORTS", "LIMITS")
+	for _, cmp := range res.Components {
+		t.Row(
+			cmp.Name, cmp.Image, cmp.State, boolFmt(cmp.Ready, nil),
+			statusPortsFmt(cmp.Ports), statusLimits(cmp.Limits).String(),
+		)
+	}
+
+	return t.Print(os.Stdout)
+}
+
+func printStatusJSON(res *api.StatusResponse) error {
+	status := engineStatus{
+		Version:    res.Version,
+		Workspace:  res.Workspace,
+		Workdir:    res.Workdir,
+		HostOS:     res.HostOs,
+		Config:     res.Config,
+		Components: []componentStatus{},
+	}
+
+	for _, cmp := range res.Components {
+		s := componentStatus{
+			Name:  cmp.Name,
+			Image: cmp.Image,
+			State: cmp.State,
+			Ready: cmp.Ready,
+			Ports: []portStatus{},
+		}
+
+		l := statusLimits(cmp.Limits)
+		s.Limits = limitsStatus{
+			CPUs:       l.CPUs,
+			Memory:     l.Memory,
+			MemorySwap: l.MemorySwap,
+			PidsLimit:  l.PidsLimit,
+		}
+
+		for _, p := range cmp.Ports {
+			s.Ports = append(s.Ports, portStatus{
+				HostIP:      p.HostIp,
+				PublicPort:  p.PublicPort,
+				PrivatePort: p.PrivatePort,
+			})
+		}
+
+		status.Components = append(status.Components, s)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(status)
+}
+
+// statusPortsFmt formats the published ports as public->private pairs
+func statusPortsFmt(ps []*api.StatusResponse_Port) string {
+	var ports []string
+	for _, p := range ps {
+		if p.PublicPort == 0 {
+			continue
+		}
+
+		public := fmt.Sprint(p.PublicPort)
+		if ip := net.ParseIP(p.HostIp); ip != nil && !ip.IsUnspecified() {
+			public = fmt.Sprintf("%s:%d", p.HostIp, p.PublicPort)
+		}
+
+		ports = append(ports, fmt.Sprintf("%s->%d", public, p.PrivatePort))
+	}
+
+	return strings.Join(ports, ",")
+}
+
+func init() {
+	rootCmd.AddCommand(&statusCmd{})
+}
+
+// statusLimits converts the resource limits of a component status
+func statusLimits(l *api.StatusResponse_Limits) docker.Limits {
+	if l == nil {
+		return docker.Limits{}
+	}
+
+	return docker.Limits{
+		CPUs:       l.Cpus,
+		Memory:     l.Memory,
+		MemorySwap: l.MemorySwap,
+		PidsLimit:  l.PidsLimit,
+	}
+}
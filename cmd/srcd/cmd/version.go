@@ -36,12 +36,19 @@ type versionCmd struct {
 
 func (c *versionCmd) Execute(args []string) error {
 	fmt.Printf("srcd cli version: %s\n", version)
-	v, err := daemon.DockerVersion()
-	if err != nil {
-		return humanizef(err, "could not get docker version")
+
+	if ctx := daemon.CurrentContext(); ctx.IsRemote() {
+		fmt.Printf("srcd context: %s (%s)\n", ctx.Name, ctx.Address)
 	}
 
-	fmt.Printf("docker version: %s\n", v)
+	if ctx := daemon.CurrentContext(); !ctx.IsRemote() || ctx.DockerHost != "" {
+		v, err := daemon.DockerVersion()
+		if err != nil {
+			return humanizef(err, "could not get docker version")
+		}
+
+		fmt.Printf("docker version: %s\n", v)
+	}
 
 	if ok, err := daemon.IsRunning(); err != nil {
 		return humanizef(err, "could not get srcd daemon version")
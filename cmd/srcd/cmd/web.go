@@ -17,8 +17,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
 	api "github.com/src-d/engine/api"
@@ -58,10 +60,10 @@ func startWebComponent(name, desc string, args []string) error {
 		return humanizef(err, "could not get daemon client")
 	}
 
-	// in case of gitbase-web we need to run gitbase first and make sure it started
+	// in case of gitbase-web we need to run gitbase first and make sure it is ready
 	if name == components.GitbaseWeb.Name {
 		timeout := 3 * time.Second
-		started := logAfterTimeoutWithServerLogs("this is taking a while, "+
+		started := logAfterTimeoutWithServerEvents("this is taking a while, "+
 			"it might take a few more minutes while we install all the required images",
 			timeout)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
@@ -74,16 +76,9 @@ func startWebComponent(name, desc string, args []string) error {
 		if err != nil {
 			return humanizef(err, "could not start gitbase")
 		}
-
-		connReady := logAfterTimeoutWithSpinner("waiting for gitbase to be ready", timeout, 0)
-		err = ensureConnReady(c)
-		connReady()
-		if err != nil {
-			return humanizef(err, "could not connect to gitbase")
-		}
 	}
 
-	started := logAfterTimeoutWithServerLogs("this is taking a while, if this is the first time you launch this web client, it might take a few more minutes while we install all the required images",
+	started := logAfterTimeoutWithServerEvents("this is taking a while, if this is the first time you launch this web client, it might take a few more minutes while we install all the required images",
 		3*time.Second)
 
 	// Might have to pull some images
@@ -103,8 +98,9 @@ func startWebComponent(name, desc string, args []string) error {
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt, os.Kill)
 
-	fmt.Printf("Go to http://localhost:%d for the %s. Press Ctrl-C to stop it.\n", res.Port, desc)
-	_ = browser.OpenURL(fmt.Sprintf("http://localhost:%d", res.Port))
+	url := fmt.Sprintf("http://%s", webHost(res.HostIp, res.Port))
+	fmt.Printf("Go to %s for the %s. Press Ctrl-C to stop it.\n", url, desc)
+	_ = browser.OpenURL(url)
 
 	<-ch
 
@@ -126,3 +122,21 @@ func init() {
 	c.AddCommand(&webSQLCmd{})
 	c.AddCommand(&webParseCmd{})
 }
+
+// webHost returns the host:port to open a web client bound to the given
+// host address
+func webHost(hostIP string, port int32) string {
+	host := "localhost"
+	if ctx := daemon.CurrentContext(); ctx.IsRemote() {
+		// the web client runs in the host of the remote engine
+		if h, _, err := net.SplitHostPort(ctx.Address); err == nil {
+			host = h
+		}
+	}
+
+	if ip := net.ParseIP(hostIP); ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() {
+		host = hostIP
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
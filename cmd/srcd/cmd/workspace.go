@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/src-d/engine/cmd/srcd/daemon"
+
+	"gopkg.in/src-d/go-cli.v0"
+)
+
+// workspaceCmd represents the workspace command
+type workspaceCmd struct {
+	cli.PlainCommand `name:"workspace" short-description:"Manage the workspaces of the local engine" long-description:"Manage the workspaces of the local engine.\n\nEach workspace serves a working directory with its own daemon, gitbase and gitbase-web, and they can run at the same time. bblfshd and bblfsh-web are shared by all of them. Create a workspace with srcd init --workspace <name> [workdir]."`
+}
+
+// workspaceCommand is embedded by the workspace subcommands. They do not load
+// the workspace, so they can be used to fix an invalid one.
+type workspaceCommand struct {
+	Command
+}
+
+// Init implements cli.Initializer
+func (c workspaceCommand) Init(a *cli.App) error {
+	if err := c.LogOptions.Init(a); err != nil {
+		return err
+	}
+
+	if err := daemon.LoadContext(c.Context); err != nil {
+		return humanizef(err, "could not load the engine context")
+	}
+
+	if daemon.CurrentContext().IsRemote() {
+		return fmt.Errorf("workspaces can only be used with the %s context", daemon.DefaultContextName)
+	}
+
+	return nil
+}
+
+// workspaceListCmd represents the workspace list command
+type workspaceListCmd struct {
+	workspaceCommand `name:"list" short-description:"List the workspaces" long-description:"List the workspaces. The one in use is marked with *"`
+}
+
+func (c *workspaceListCmd) Execute(args []string) error {
+	workspaces, err := daemon.ListWorkspaces()
+	if err != nil {
+		return humanizef(err, "could not list workspaces")
+	}
+
+	currentName, err := daemon.CurrentWorkspaceName()
+	if err != nil {
+		return humanizef(err, "could not get current workspace")
+	}
+
+	t := NewTable("%s", "%s", "%s")
+	t.Header("NAME", "WORKDIR", "RUNNING")
+	for _, w := range workspaces {
+		name := w.Name
+		if name == currentName {
+			name += " *"
+		}
+
+		t.Row(name, w.WorkDir, boolFmt(w.IsRunning()))
+	}
+
+	return t.Print(os.Stdout)
+}
+
+// workspaceUseCmd represents the workspace use command
+type workspaceUseCmd struct {
+	workspaceCommand `name:"use" short-description:"Set the workspace used by srcd" long-description:"Set the workspace used by the next srcd commands. It can be overridden with the --workspace flag or the SRCD_WORKSPACE environment variable."`
+
+	Args struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *workspaceUseCmd) Execute(args []string) error {
+	if err := daemon.UseWorkspace(c.Args.Name); err != nil {
+		return humanizef(err, "could not use workspace")
+	}
+
+	fmt.Printf("using workspace %s\n", c.Args.Name)
+	return nil
+}
+
+// workspaceRmCmd represents the workspace rm command
+type workspaceRmCmd struct {
+	workspaceCommand `name:"rm" short-description:"Remove a workspace" long-description:"Remove the containers, the gitbase index and the state of a workspace. If it is the one in use, the default workspace is selected.\n\nThe default workspace can't be removed, use srcd prune instead."`
+
+	Args struct {
+		Name string `positional-arg-name:"name" required:"yes"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *workspaceRmCmd) Execute(args []string) error {
+	if err := daemon.RemoveWorkspace(c.Args.Name); err != nil {
+		return humanizef(err, "could not remove workspace")
+	}
+
+	fmt.Printf("workspace %s removed\n", c.Args.Name)
+	return nil
+}
+
+func init() {
+	c := rootCmd.AddCommand(&workspaceCmd{})
+	c.AddCommand(&workspaceListCmd{})
+	c.AddCommand(&workspaceUseCmd{})
+	c.AddCommand(&workspaceRmCmd{})
+}
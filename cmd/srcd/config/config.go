@@ -18,6 +18,14 @@ import (
 // DefaultFileContents is the default text for an empty config.yml file
 var DefaultFileContents = `# Any change in the exposed ports will require you to run srcd init (or stop)
 
+# Host address the ports are bound to. Use 0.0.0.0 to make the components
+# reachable from other hosts. It can be set for each component too
+host_ip: 127.0.0.1
+
+# Time a component has to become ready after it is started, before the
+# components that depend on it are started
+ready_timeout: 5m
+
 components:
   bblfshd:
     port: 9432
@@ -72,6 +80,10 @@ func Read(configFile string) error {
 		return errors.Wrapf(err, "config file %s does not follow the expected format", configFile)
 	}
 
+	if err := File.Validate(); err != nil {
+		return errors.Wrapf(err, "config file %s is not valid", configFile)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,294 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/src-d/engine/auth"
+	"github.com/src-d/engine/docker"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultContextName is the name of the context of the engine run by srcd in
+// the local docker
+const DefaultContextName = "default"
+
+const (
+	contextsDirName        = "contexts"
+	contextFileName        = "context.json"
+	currentContextFileName = "current_context"
+)
+
+var contextNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Context is an engine the CLI works with. The default context is the engine
+// run by srcd in the local docker, any other context is a remote engine.
+type Context struct {
+	// Name identifies the context
+	Name string `json:"name"`
+	// Address is the host:port of the remote daemon
+	Address string `json:"address,omitempty"`
+	// DockerHost is the docker daemon running the remote engine, used by the
+	// commands that manage its containers directly. If it is empty those
+	// commands cannot be used
+	DockerHost string `json:"docker_host,omitempty"`
+	// WorkDir is a label for the working directory served by the engine
+	WorkDir string `json:"workdir,omitempty"`
+}
+
+// IsRemote returns whether the context is a remote engine
+func (c *Context) IsRemote() bool {
+	return c.Name != DefaultContextName
+}
+
+// current is the context used by the daemon client and the docker client
+var current = &Context{Name: DefaultContextName}
+
+// CurrentContext returns the context loaded with LoadContext
+func CurrentContext() *Context {
+	return current
+}
+
+// LoadContext sets the context with the given name as the one used by the
+// daemon client and the docker client. If name is empty, the context selected
+// with UseContext is loaded.
+func LoadContext(name string) error {
+	if name == "" {
+		var err error
+		if name, err = CurrentContextName(); err != nil {
+			return err
+		}
+	}
+
+	c, err := readContext(name)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !c.IsRemote():
+		docker.SetHost("")
+	case c.DockerHost != "":
+		docker.SetHost(c.DockerHost)
+	default:
+		docker.SetUnavailable(fmt.Errorf(
+			"the context %s has no docker host, "+
+				"create it with --docker-host to use this command", c.Name))
+	}
+
+	current = c
+	return nil
+}
+
+// CreateContext stores a new context for a remote engine. tlsDir is the
+// directory with the CA certificate and the access token of the remote daemon,
+// they are copied into the context.
+func CreateContext(c Context, tlsDir string) error {
+	if !contextNameRegexp.MatchString(c.Name) {
+		return fmt.Errorf("invalid context name %q, only letters, digits, '_', '.' and '-' are allowed", c.Name)
+	}
+
+	if c.Name == DefaultContextName {
+		return fmt.Errorf("the context %s already exists", c.Name)
+	}
+
+	if _, _, err := net.SplitHostPort(c.Address); err != nil {
+		return fmt.Errorf("invalid daemon address %q, it must be host:port", c.Address)
+	}
+
+	if _, err := auth.ClientTLSConfig(tlsDir); err != nil {
+		return err
+	}
+
+	if _, err := auth.ReadToken(tlsDir); err != nil {
+		return err
+	}
+
+	dir, err := contextDir(c.Name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("the context %s already exists", c.Name)
+	}
+
+	if err := writeContext(dir, c, tlsDir); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+
+	return nil
+}
+
+func writeContext(dir string, c Context, tlsDir string) error {
+	credsDir := filepath.Join(dir, credentialsDirName)
+	if err := os.MkdirAll(credsDir, 0700); err != nil {
+		return errors.Wrapf(err, "can't create context directory %s", dir)
+	}
+
+	for _, name := range []string{auth.CACertFile, auth.TokenFile} {
+		b, err := ioutil.ReadFile(filepath.Join(tlsDir, name))
+		if err != nil {
+			return errors.Wrapf(err, "can't read %s", name)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(credsDir, name), b, 0600); err != nil {
+			return errors.Wrapf(err, "can't write %s", name)
+		}
+	}
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "can't encode context")
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, contextFileName), b, 0600)
+	return errors.Wrap(err, "can't write context file")
+}
+
+// UseContext selects the context used by the next srcd commands
+func UseContext(name string) error {
+	if _, err := readContext(name); err != nil {
+		return err
+	}
+
+	d, err := datadir()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(d, currentContextFileName)
+	if name == DefaultContextName {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "can't remove current context file")
+		}
+
+		return nil
+	}
+
+	if err := os.MkdirAll(d, 0755); err != nil {
+		return errors.Wrapf(err, "can't create engine data directory")
+	}
+
+	err = ioutil.WriteFile(path, []byte(name), 0644)
+	return errors.Wrap(err, "can't write current context file")
+}
+
+// CurrentContextName returns the name of the context selected with
+// UseContext
+func CurrentContextName() (string, error) {
+	d, err := datadir()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(d, currentContextFileName))
+	if os.IsNotExist(err) {
+		return DefaultContextName, nil
+	}
+
+	if err != nil {
+		return "", errors.Wrap(err, "can't read current context file")
+	}
+
+	name := strings.TrimSpace(string(b))
+	if name == "" {
+		return DefaultContextName, nil
+	}
+
+	return name, nil
+}
+
+// ListContexts returns all the contexts, the default one first and the rest
+// sorted by name
+func ListContexts() ([]*Context, error) {
+	def, err := readContext(DefaultContextName)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := datadir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(filepath.Join(d, contextsDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "can't list contexts")
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	contexts := []*Context{def}
+	for _, name := range names {
+		c, err := readContext(name)
+		if err != nil {
+			return nil, err
+		}
+
+		contexts = append(contexts, c)
+	}
+
+	return contexts, nil
+}
+
+func readContext(name string) (*Context, error) {
+	if name == DefaultContextName {
+		c := &Context{Name: DefaultContextName}
+		if opts, err := readState(); err == nil && opts != nil {
+			c.WorkDir = opts.WorkDir
+		}
+
+		return c, nil
+	}
+
+	if !contextNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("the context %s does not exist", name)
+	}
+
+	dir, err := contextDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, contextFileName))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("the context %s does not exist", name)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't read context %s", name)
+	}
+
+	var c Context
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, errors.Wrapf(err, "can't decode context %s", name)
+	}
+
+	c.Name = name
+	return &c, nil
+}
+
+func contextDir(name string) (string, error) {
+	d, err := datadir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(d, contextsDirName, name), nil
+}
@@ -5,14 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
-	"path"
+	"os/user"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"time"
 
 	"github.com/src-d/engine/api"
+	"github.com/src-d/engine/auth"
 	"github.com/src-d/engine/cmd/srcd/config"
 	"github.com/src-d/engine/components"
 	"github.com/src-d/engine/docker"
@@ -23,7 +25,10 @@ import (
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"gopkg.in/src-d/go-log.v1"
+	yaml "gopkg.in/yaml.v2"
 )
 
 const (
@@ -31,32 +36,110 @@ const (
 	// maxMessageSize overrides default grpc max. message size to receive
 	maxMessageSize = 100 * 1024 * 1024 // 100MB
 	stateFileName  = ".state.json"
+	// credentialsDirName is the directory inside the data dir that holds
+	// the daemon credentials
+	credentialsDirName = "tls"
+	// credentialsMountPath is where the credentials are mounted in the
+	// daemon container
+	credentialsMountPath = "/etc/srcd/tls"
 )
 
 // cli version set by src-d command
 var cliVersion = ""
 
-// SetCliVersion sets cli version
+// SetCliVersion sets cli version, and the owner of the docker objects created
+// by srcd
 func SetCliVersion(v string) {
 	cliVersion = v
+	docker.SetOwner(docker.Owner{User: User(), Version: v})
+}
+
+// User returns the name of the user running srcd, or an empty string if it
+// can't be known
+func User() string {
+	u, err := user.Current()
+	if err != nil {
+		log.Debugf("could not get the current user: %s", err)
+		return ""
+	}
+
+	return u.Username
+}
+
+// WorkdirHash returns the hash of the working directory of the local daemon,
+// or an empty string if it is not known
+func WorkdirHash() (string, error) {
+	if current.IsRemote() {
+		return "", nil
+	}
+
+	opts, err := readState()
+	if err != nil || opts == nil {
+		return "", err
+	}
+
+	return components.WorkdirHash(filepath.ToSlash(opts.WorkDir)), nil
 }
 
 func DockerVersion() (string, error) { return docker.Version() }
-func IsRunning() (bool, error)       { return docker.IsRunning(components.Daemon.Name, "") }
+
+// IsRunning returns whether the daemon is running. The daemon of a remote
+// context without docker host is not managed by srcd, and it is assumed to be
+// running
+func IsRunning() (bool, error) {
+	if current.IsRemote() && current.DockerHost == "" {
+		return true, nil
+	}
+
+	return docker.IsRunning(components.Daemon.Name, "")
+}
+
+// IsBblfshdShared returns whether bblfshd is also used by the daemon of
+// another workspace, in which case it is not restarted by srcd init
+func IsBblfshdShared() (bool, error) {
+	if current.IsRemote() {
+		return false, nil
+	}
+
+	return otherWorkspaceRunning()
+}
+
+// errRemoteContext is returned by the operations that can only be done on the
+// local engine
+func errRemoteContext(op string) error {
+	return fmt.Errorf("can't %s of the remote context %s, "+
+		"run srcd on the host of the engine instead", op, current.Name)
+}
 
 // Kill stops the daemon, and any of its dependencies. If it was not running it
 // is ignored and does not produce an error
 func Kill() error {
+	if current.IsRemote() {
+		return errRemoteContext("stop the daemon")
+	}
+
+	// the containers are removed whatever their image is, it can be set in
+	// the config file
 	cmps, err := components.List(
 		context.Background(),
-		true,
+		false,
 		components.IsWorkingDirDependant,
-		components.IsRunning)
+		components.IsContainerRunning)
+	if err != nil {
+		return err
+	}
+
+	// bblfshd is shared by the workspaces
+	shared, err := otherWorkspaceRunning()
 	if err != nil {
 		return err
 	}
 
 	for _, cmp := range cmps {
+		if shared && cmp.Name == components.Bblfshd.Name {
+			continue
+		}
+
 		log.Infof("removing container %s", cmp.Name)
 
 		if err := cmp.Kill(); err != nil {
@@ -67,37 +150,119 @@ func Kill() error {
 	return nil
 }
 
-// CleanUp removes all resources created by daemon on host
+// CleanUp removes all resources created by daemon on host, including the
+// state of every workspace
 func CleanUp() error {
+	if current.IsRemote() {
+		return nil
+	}
+
 	datadir, err := datadir()
 	if err != nil {
 		return err
 	}
 
-	stateFile := filepath.Join(datadir, stateFileName)
-	return os.RemoveAll(stateFile)
+	for _, name := range []string{
+		stateFileName,
+		workspacesDirName,
+		currentWorkspaceFileName,
+	} {
+		if err := os.RemoveAll(filepath.Join(datadir, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Client will return a new EngineClient to interact with the daemon. If the
 // daemon is not started already, it will start it at the working directory.
+// For a remote context, it connects to the daemon at the context address.
 func Client() (api.EngineClient, error) {
+	conn, err := connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewEngineClient(conn), nil
+}
+
+func connect() (*grpc.ClientConn, error) {
+	if current.IsRemote() {
+		dir, err := contextDir(current.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return dial(current.Address, filepath.Join(dir, credentialsDirName))
+	}
+
 	info, err := ensureStarted()
 	if err != nil {
 		return nil, err
 	}
 
-	addr := fmt.Sprintf("0.0.0.0:%d", info.Ports[0].PublicPort)
-	// TODO(campoy): add security
+	dir, err := credentialsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := daemonAddr(info)
+	if err != nil {
+		return nil, err
+	}
+
+	return dial(addr, dir)
+}
+
+// dial connects to the daemon at addr, using the credentials stored in dir
+func dial(addr, dir string) (*grpc.ClientConn, error) {
+	tlsConfig, err := auth.ClientTLSConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := auth.ReadToken(dir)
+	if err != nil {
+		return nil, err
+	}
+
 	conn, err := grpc.Dial(addr,
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(maxMessageSize),
 		),
-		grpc.WithInsecure())
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithPerRPCCredentials(auth.NewTokenCredentials(token)))
 	if err != nil {
 		return nil, err
 	}
 
-	return api.NewEngineClient(conn), nil
+	return conn, nil
+}
+
+// daemonAddr returns the address to dial the daemon running in the given
+// container, using the host address its port is bound to
+func daemonAddr(info *docker.Container) (string, error) {
+	for _, p := range info.Ports {
+		if int(p.PrivatePort) != components.DaemonPort || p.PublicPort == 0 {
+			continue
+		}
+
+		return net.JoinHostPort(dialableIP(p.IP), strconv.Itoa(int(p.PublicPort))), nil
+	}
+
+	return "", fmt.Errorf("the daemon container does not publish port %d", components.DaemonPort)
+}
+
+// dialableIP returns the loopback address if ip is empty or unspecified
+// (bound to all the addresses), or ip otherwise
+func dialableIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsUnspecified() {
+		return "127.0.0.1"
+	}
+
+	return ip
 }
 
 // startOptions is a configuration for src-d daemon
@@ -106,18 +271,18 @@ type startOptions struct {
 	Config  *api.Config `json:"config"`
 }
 
-// Save persists configuration to a file
+// Save persists configuration to the state file of the current workspace
 func (o *startOptions) Save() error {
-	d, err := datadir()
+	p, err := statePath(components.Workspace())
 	if err != nil {
 		return err
 	}
 
-	if err := os.MkdirAll(d, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
 		return errors.Wrapf(err, "can't create engine data directory")
 	}
 
-	f, err := os.Create(path.Join(d, stateFileName))
+	f, err := os.Create(p)
 	if err != nil {
 		return errors.Wrapf(err, "can't open state file for save")
 	}
@@ -128,6 +293,10 @@ func (o *startOptions) Save() error {
 }
 
 func Start(workdir string) error {
+	if current.IsRemote() {
+		return errRemoteContext("start the daemon")
+	}
+
 	opts, err := saveState(workdir)
 	if err != nil {
 		return err
@@ -149,7 +318,14 @@ func saveState(workdir string) (startOptions, error) {
 }
 
 func GetLogs() (io.ReadCloser, error) {
-	info, err := ensureStarted()
+	var info *docker.Container
+	var err error
+	if current.IsRemote() {
+		info, err = docker.Info(components.Daemon.Name)
+	} else {
+		info, err = ensureStarted()
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -166,23 +342,76 @@ func ensureStarted() (*docker.Container, error) {
 		return docker.Info(components.Daemon.Name)
 	}
 
-	d, err := datadir()
+	opts, err := readState()
 	if err != nil {
 		return nil, err
 	}
 
-	statePath := path.Join(d, stateFileName)
-	if _, err := os.Stat(statePath); os.IsNotExist(err) {
+	if opts == nil {
 		wd, err := os.Getwd()
 		if err != nil {
 			return nil, err
 		}
-		opts, err := saveState(wd)
+		newOpts, err := saveState(wd)
 		if err != nil {
 			return nil, err
 		}
 
-		return start(opts)
+		return start(newOpts)
+	}
+
+	return start(*opts)
+}
+
+// Config returns the config the daemon was started with. For a remote context,
+// it is requested to the daemon.
+func Config() (*api.Config, error) {
+	var conf api.Config
+	if current.IsRemote() {
+		client, err := Client()
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := client.Status(context.Background(), &api.StatusRequest{})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := yaml.Unmarshal([]byte(res.Config), &conf); err != nil {
+			return nil, errors.Wrapf(err, "can't decode the daemon config")
+		}
+	} else {
+		opts, err := readState()
+		if err != nil {
+			return nil, err
+		}
+
+		if opts != nil && opts.Config != nil {
+			conf = *opts.Config
+		}
+	}
+
+	conf.SetDefaults()
+	return &conf, nil
+}
+
+// readState returns the options saved in the state file of the current
+// workspace, or nil if there is no state file
+func readState() (*startOptions, error) {
+	return readWorkspaceState(components.Workspace())
+}
+
+// readWorkspaceState returns the options saved in the state file of a
+// workspace, or nil if there is no state file
+func readWorkspaceState(workspace string) (*startOptions, error) {
+	statePath, err := statePath(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(statePath); os.IsNotExist(err) {
+		return nil, nil
 	}
 
 	f, err := os.Open(statePath)
@@ -197,15 +426,65 @@ func ensureStarted() (*docker.Container, error) {
 		return nil, errors.Wrapf(err, "can't decode state file")
 	}
 
-	return start(opts)
+	return &opts, nil
 }
 
+// start runs the daemon container if it is not running, and waits until the
+// daemon is ready
 func start(opts startOptions) (*docker.Container, error) {
-	return docker.InfoOrStart(
+	info, err := docker.InfoOrStart(
 		context.Background(),
 		components.Daemon.Name,
 		createDaemon(opts),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := api.DefaultReadyTimeout
+	if opts.Config != nil && opts.Config.ReadyTimeout != 0 {
+		timeout = opts.Config.ReadyTimeout
+	}
+
+	err = docker.WaitReady(context.Background(), components.Daemon.Name, daemonReady(info), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// daemonReady returns a readiness probe that checks the health service of
+// the daemon running in the given container
+func daemonReady(info *docker.Container) docker.ReadyFunc {
+	return func(ctx context.Context) error {
+		addr, err := daemonAddr(info)
+		if err != nil {
+			return err
+		}
+
+		dir, err := credentialsDir()
+		if err != nil {
+			return err
+		}
+
+		conn, err := dial(addr, dir)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+
+		if res.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("daemon health status is %s", res.Status)
+		}
+
+		return nil
+	}
 }
 
 func createDaemon(opts startOptions) docker.StartFunc {
@@ -228,7 +507,27 @@ func createDaemon(opts startOptions) docker.StartFunc {
 			return err
 		}
 
+		// the credentials are created here, and not by the daemon, so the
+		// files belong to the user
+		credsDir, err := credentialsDir()
+		if err != nil {
+			return err
+		}
+
+		if err := auth.EnsureCredentials(credsDir); err != nil {
+			return err
+		}
+
+		credsHostPath, err := docker.HostPath(filepath.ToSlash(credsDir))
+		if err != nil {
+			return errors.Wrapf(err, "can't process host path for %s", credsDir)
+		}
+
+		// the port of the daemon of a workspace is chosen by docker
 		hostPort := strconv.Itoa(conf.Components.Daemon.Port)
+		if components.Workspace() != components.DefaultWorkspace {
+			hostPort = "0"
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -244,22 +543,50 @@ func createDaemon(opts startOptions) docker.StartFunc {
 				fmt.Sprintf("--workdir=%s", workdir),
 				fmt.Sprintf("--host-os=%s", runtime.GOOS),
 				fmt.Sprintf("--config=%s", conf.AsYaml()),
+				fmt.Sprintf("--credentials-dir=%s", credentialsMountPath),
+				fmt.Sprintf("--user=%s", User()),
+				fmt.Sprintf("--workspace=%s", components.Workspace()),
+			},
+			Labels: map[string]string{
+				docker.LabelWorkdir: components.WorkdirHash(workdir),
 			},
 		}
 
 		host := &container.HostConfig{
-			PortBindings: nat.PortMap{daemonPort: {{HostPort: hostPort}}},
-			Mounts: []mount.Mount{{
-				Type:   mount.TypeBind,
-				Source: dockerSocket,
-				Target: dockerSocket,
-			}},
+			PortBindings: nat.PortMap{daemonPort: {{
+				HostIP:   conf.Components.Daemon.HostIP,
+				HostPort: hostPort,
+			}}},
+			Mounts: []mount.Mount{
+				{
+					Type:   mount.TypeBind,
+					Source: dockerSocket,
+					Target: dockerSocket,
+				},
+				{
+					Type:     mount.TypeBind,
+					Source:   credsHostPath,
+					Target:   credentialsMountPath,
+					ReadOnly: true,
+				},
+			},
 		}
 
 		return docker.Start(ctx, config, host, cmp.Name)
 	}
 }
 
+// credentialsDir returns the directory with the TLS certificates and the
+// access token used to connect to the daemon
+func credentialsDir() (string, error) {
+	d, err := datadir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(d, credentialsDirName), nil
+}
+
 func datadir() (string, error) {
 	homedir, err := homedir.Dir()
 	if err != nil {
@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialableIP(t *testing.T) {
+	cases := map[string]string{
+		"":            "127.0.0.1",
+		"0.0.0.0":     "127.0.0.1",
+		"::":          "127.0.0.1",
+		"not-an-ip":   "127.0.0.1",
+		"127.0.0.1":   "127.0.0.1",
+		"192.168.1.2": "192.168.1.2",
+		"::1":         "::1",
+	}
+
+	for ip, expected := range cases {
+		require.Equal(t, expected, dialableIP(ip), "ip %q", ip)
+	}
+}
+
+func TestDaemonAddr(t *testing.T) {
+	require := require.New(t)
+
+	addr, err := daemonAddr(&docker.Container{Ports: []types.Port{
+		{PrivatePort: 8080, PublicPort: 8080, IP: "0.0.0.0"},
+		{PrivatePort: components.DaemonPort, PublicPort: 4242, IP: "192.168.1.2"},
+	}})
+	require.NoError(err)
+	require.Equal("192.168.1.2:4242", addr)
+
+	addr, err = daemonAddr(&docker.Container{Ports: []types.Port{
+		{PrivatePort: components.DaemonPort, PublicPort: 4242, IP: "::"},
+	}})
+	require.NoError(err)
+	require.Equal("127.0.0.1:4242", addr)
+
+	addr, err = daemonAddr(&docker.Container{Ports: []types.Port{
+		{PrivatePort: components.DaemonPort, PublicPort: 4242, IP: "::1"},
+	}})
+	require.NoError(err)
+	require.Equal("[::1]:4242", addr)
+
+	// the port is exposed but not published
+	_, err = daemonAddr(&docker.Container{Ports: []types.Port{
+		{PrivatePort: components.DaemonPort},
+	}})
+	require.Error(err)
+}
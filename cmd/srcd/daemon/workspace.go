@@ -0,0 +1,300 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
+
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-log.v1"
+)
+
+const (
+	workspacesDirName        = "workspaces"
+	currentWorkspaceFileName = "current_workspace"
+)
+
+// workspaceNameRegexp matches the valid workspace names. They are part of the
+// container names, and can't contain components.WorkspaceSeparator.
+var workspaceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.-]*$`)
+
+// Workspace is a working directory served by its own daemon, gitbase and
+// gitbase-web, that can run at the same time as the ones of other workspaces.
+// bblfshd and bblfsh-web are shared by all the workspaces.
+type Workspace struct {
+	// Name identifies the workspace
+	Name string
+	// WorkDir is the working directory, empty if the daemon of the
+	// workspace was never started
+	WorkDir string
+}
+
+// IsRunning returns whether the daemon of the workspace is running
+func (w *Workspace) IsRunning() (bool, error) {
+	return docker.IsRunning(components.WorkspaceContainerName(w.Name, "daemon"), "")
+}
+
+// LoadWorkspace sets the workspace of the components used by the next
+// operations. If name is empty, the workspace selected with UseWorkspace is
+// loaded. The workspaces can only be used with the default context.
+func LoadWorkspace(name string) error {
+	explicit := name != ""
+	if !explicit {
+		var err error
+		if name, err = CurrentWorkspaceName(); err != nil {
+			return err
+		}
+	}
+
+	if !workspaceNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid workspace name %q, only letters, digits, '.' and '-' are allowed", name)
+	}
+
+	if current.IsRemote() {
+		if explicit && name != components.DefaultWorkspace {
+			return fmt.Errorf("workspaces can only be used with the %s context", DefaultContextName)
+		}
+
+		name = components.DefaultWorkspace
+	}
+
+	components.SetWorkspace(name)
+	// the objects of a remote engine are labeled with the user of its host
+	docker.SetOwner(docker.Owner{
+		User:      User(),
+		AnyUser:   current.IsRemote(),
+		Version:   cliVersion,
+		Workspace: name,
+	})
+	return nil
+}
+
+// UseWorkspace selects the workspace used by the next srcd commands. The
+// workspace must have been created with srcd init.
+func UseWorkspace(name string) error {
+	if _, err := readWorkspace(name); err != nil {
+		return err
+	}
+
+	d, err := datadir()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(d, currentWorkspaceFileName)
+	if name == components.DefaultWorkspace {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "can't remove current workspace file")
+		}
+
+		return nil
+	}
+
+	if err := os.MkdirAll(d, 0755); err != nil {
+		return errors.Wrapf(err, "can't create engine data directory")
+	}
+
+	err = ioutil.WriteFile(path, []byte(name), 0644)
+	return errors.Wrap(err, "can't write current workspace file")
+}
+
+// CurrentWorkspaceName returns the name of the workspace selected with
+// UseWorkspace
+func CurrentWorkspaceName() (string, error) {
+	d, err := datadir()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(d, currentWorkspaceFileName))
+	if os.IsNotExist(err) {
+		return components.DefaultWorkspace, nil
+	}
+
+	if err != nil {
+		return "", errors.Wrap(err, "can't read current workspace file")
+	}
+
+	name := strings.TrimSpace(string(b))
+	if name == "" {
+		return components.DefaultWorkspace, nil
+	}
+
+	return name, nil
+}
+
+// ListWorkspaces returns all the workspaces, the default one first and the
+// rest sorted by name
+func ListWorkspaces() ([]*Workspace, error) {
+	def, err := readWorkspace(components.DefaultWorkspace)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := datadir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(filepath.Join(d, workspacesDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "can't list workspaces")
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	workspaces := []*Workspace{def}
+	for _, name := range names {
+		w, err := readWorkspace(name)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, w)
+	}
+
+	return workspaces, nil
+}
+
+// RemoveWorkspace removes the containers, volumes and state of a workspace.
+// If it is the one in use, the default workspace is selected.
+func RemoveWorkspace(name string) error {
+	if current.IsRemote() {
+		return errRemoteContext("remove a workspace")
+	}
+
+	if name == components.DefaultWorkspace {
+		return fmt.Errorf("the %s workspace can't be removed, use srcd prune instead", name)
+	}
+
+	if _, err := readWorkspace(name); err != nil {
+		return err
+	}
+
+	cs, err := docker.List()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cs {
+		if c.Labels[docker.LabelWorkspace] != name || len(c.Names) == 0 {
+			continue
+		}
+
+		cname := strings.TrimLeft(c.Names[0], "/")
+		log.Infof("removing container %s", cname)
+
+		if err := docker.RemoveContainer(cname); err != nil {
+			return err
+		}
+	}
+
+	vols, err := docker.ListOwnedVolumes(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, vol := range vols {
+		if vol.Labels[docker.LabelWorkspace] != name {
+			continue
+		}
+
+		// the volume of the gitbase index can be used by another workspace
+		// with the same working directory
+		log.Infof("removing volume %s", vol.Name)
+		if err := docker.RemoveVolume(context.Background(), vol.Name); err != nil {
+			log.Warningf("could not remove volume %s: %s", vol.Name, err)
+		}
+	}
+
+	p, err := statePath(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(filepath.Dir(p)); err != nil {
+		return errors.Wrapf(err, "can't remove the state of workspace %s", name)
+	}
+
+	currentName, err := CurrentWorkspaceName()
+	if err != nil {
+		return err
+	}
+
+	if currentName == name {
+		return UseWorkspace(components.DefaultWorkspace)
+	}
+
+	return nil
+}
+
+func readWorkspace(name string) (*Workspace, error) {
+	if !workspaceNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("the workspace %s does not exist", name)
+	}
+
+	opts, err := readWorkspaceState(name)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{Name: name}
+	if opts != nil {
+		w.WorkDir = opts.WorkDir
+	} else if name != components.DefaultWorkspace {
+		return nil, fmt.Errorf("the workspace %s does not exist, "+
+			"create it with srcd init --workspace %s", name, name)
+	}
+
+	return w, nil
+}
+
+// statePath returns the path of the state file of a workspace
+func statePath(workspace string) (string, error) {
+	d, err := datadir()
+	if err != nil {
+		return "", err
+	}
+
+	if workspace == components.DefaultWorkspace {
+		return filepath.Join(d, stateFileName), nil
+	}
+
+	return filepath.Join(d, workspacesDirName, workspace, stateFileName), nil
+}
+
+// otherWorkspaceRunning returns whether the daemon of a workspace other than
+// the current one is running
+func otherWorkspaceRunning() (bool, error) {
+	cs, err := docker.List()
+	if err != nil {
+		return false, err
+	}
+
+	for _, c := range cs {
+		ws, ok := c.Labels[docker.LabelWorkspace]
+		if !ok || ws == components.Workspace() || c.State != "running" {
+			continue
+		}
+
+		if c.Labels[docker.LabelComponent] == components.WorkspaceContainerName(ws, "daemon") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
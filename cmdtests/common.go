@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/src-d/engine/cmd/srcd/daemon"
 	"github.com/src-d/engine/docker"
 	"github.com/stretchr/testify/suite"
 )
@@ -25,6 +26,9 @@ func init() {
 	if os.Getenv("SRCD_BIN") != "" {
 		srcdBin = os.Getenv("SRCD_BIN")
 	}
+
+	// the containers are found by the labels srcd sets on them
+	docker.SetOwner(docker.Owner{User: daemon.User()})
 }
 
 type IntegrationSuite struct {
@@ -30,7 +30,7 @@ func (s *ComponentsTestSuite) TestListStopped() {
 	require.NoError(r.Error, r.Combined())
 
 	expected := regexp.MustCompile(
-		`^IMAGE +INSTALLED +RUNNING +PORT +CONTAINER NAME
+		`^IMAGE +INSTALLED +RUNNING +PORT +VOLUMES +CONTAINER NAME
 bblfsh/bblfshd:\S+ +(yes|no) +no +(\d+)? +srcd-cli-bblfshd
 bblfsh/web:\S+ +(yes|no) +no +(\d+)? +srcd-cli-bblfsh-web
 mysql:\S+ +(yes|no) +no +(\d+)? +srcd-cli-mysql-cli
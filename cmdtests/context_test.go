@@ -0,0 +1,141 @@
+// +build integration
+
+package cmdtests_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/src-d/engine/auth"
+	"github.com/src-d/engine/cmdtests"
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContextTestSuite struct {
+	cmdtests.IntegrationTmpDirSuite
+	home string
+}
+
+func TestContextTestSuite(t *testing.T) {
+	s := ContextTestSuite{IntegrationTmpDirSuite: cmdtests.NewIntegrationTmpDirSuite()}
+	suite.Run(t, &s)
+}
+
+func (s *ContextTestSuite) SetupTest() {
+	s.IntegrationTmpDirSuite.SetupTest()
+
+	// To test $HOME/.srcd/contexts without breaking any existing installation
+	s.home = os.Getenv("HOME")
+	os.Setenv("HOME", filepath.Join(s.TestDir, "home"))
+}
+
+func (s *ContextTestSuite) TearDownTest() {
+	os.Setenv("HOME", s.home)
+	s.IntegrationTmpDirSuite.TearDownTest()
+}
+
+func (s *ContextTestSuite) TestCreateUseList() {
+	require := s.Require()
+
+	tlsDir := filepath.Join(s.TestDir, "tls")
+	require.NoError(auth.EnsureCredentials(tlsDir))
+
+	r := s.RunCommand("context", "list")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(regexp.MustCompile(`(?m)^default \*\s+local`), r.Stdout())
+
+	r = s.RunCommand("context", "create", "remote",
+		"--address", "engine.example.com:4242",
+		"--tls-dir", tlsDir,
+		"--workdir", "/srv/repos")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("context", "create", "remote",
+		"--address", "engine.example.com:4242",
+		"--tls-dir", tlsDir)
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "the context remote already exists")
+
+	r = s.RunCommand("context", "create", "other",
+		"--address", "engine.example.com",
+		"--tls-dir", tlsDir)
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "invalid daemon address")
+
+	r = s.RunCommand("context", "use", "remote")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("context", "list")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(regexp.MustCompile(`(?m)^default\s+local`), r.Stdout())
+	require.Regexp(regexp.MustCompile(`(?m)^remote \*\s+engine.example.com:4242\s+/srv/repos`), r.Stdout())
+
+	r = s.RunCommand("context", "use", "missing")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "the context missing does not exist")
+
+	r = s.RunCommand("context", "use", "default")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("context", "list")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(regexp.MustCompile(`(?m)^default \*\s+local`), r.Stdout())
+}
+
+func (s *ContextTestSuite) TestRemoteUser() {
+	require := s.Require()
+
+	c, err := docker.GetClient()
+	require.NoError(err)
+
+	image := components.MysqlCli
+	require.NoError(docker.EnsureInstalled(image.Image, image.Version))
+
+	// the daemon of a remote engine is labeled with the user of the remote
+	// host, not the local one
+	res, err := c.ContainerCreate(context.Background(), &container.Config{
+		Image: image.ImageWithVersion(),
+		Cmd:   []string{"sleep", "600"},
+		Labels: map[string]string{
+			docker.LabelComponent: components.Daemon.Name,
+			docker.LabelUser:      "someone-else",
+		},
+	}, nil, nil, components.Daemon.Name)
+	require.NoError(err)
+	defer c.ContainerRemove(context.Background(), res.ID, types.ContainerRemoveOptions{Force: true})
+	require.NoError(c.ContainerStart(context.Background(), res.ID, types.ContainerStartOptions{}))
+
+	// the local engine does not use the daemon of another user
+	r := s.RunCommand("version")
+	require.NoError(r.Error, r.Combined())
+	require.Contains(r.Stdout(), "srcd daemon version: not running")
+
+	tlsDir := filepath.Join(s.TestDir, "tls")
+	require.NoError(auth.EnsureCredentials(tlsDir))
+
+	dockerHost := os.Getenv("DOCKER_HOST")
+	if dockerHost == "" {
+		dockerHost = client.DefaultDockerHost
+	}
+
+	// nothing listens at the address, the remote daemon is found running
+	// and srcd fails to connect to it
+	r = s.RunCommand("context", "create", "remote",
+		"--address", "127.0.0.1:1",
+		"--tls-dir", tlsDir,
+		"--docker-host", dockerHost)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("version", "--context", "remote")
+	require.Error(r.Error)
+	require.NotContains(r.Stdout(), "srcd daemon version: not running")
+	require.Contains(r.Stderr(), "could not get daemon version")
+}
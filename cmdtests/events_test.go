@@ -0,0 +1,52 @@
+// +build integration
+
+package cmdtests_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/src-d/engine/cmdtests"
+	"github.com/src-d/engine/components"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/icmd"
+)
+
+type EventsTestSuite struct {
+	cmdtests.IntegrationTmpDirSuite
+}
+
+func TestEventsTestSuite(t *testing.T) {
+	s := EventsTestSuite{IntegrationTmpDirSuite: cmdtests.NewIntegrationTmpDirSuite()}
+	suite.Run(t, &s)
+}
+
+func (s *EventsTestSuite) TestHistory() {
+	require := s.Require()
+
+	r := s.RunInit(s.TestDir)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "SELECT 1")
+	require.NoError(r.Error, r.Combined())
+
+	// the command streams the events until it is killed
+	r = s.RunCmd("events", []string{"--history", "--json"}, icmd.WithTimeout(5*time.Second))
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(r.Stdout()), "\n") {
+		var e struct {
+			Type      string
+			Component string
+		}
+		require.NoError(json.Unmarshal([]byte(line), &e), line)
+
+		seen[e.Type+" "+e.Component] = true
+	}
+
+	require.True(seen["started "+components.Gitbase.Name])
+	require.True(seen["ready "+components.Gitbase.Name])
+	require.True(seen["started "+components.Bblfshd.Name])
+}
@@ -4,6 +4,7 @@ package cmdtests_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
@@ -190,12 +191,12 @@ func (s *InitTestSuite) TestChangeWorkdir() {
 	r = s.RunCommand("sql", "select * from repositories")
 	require.NoError(r.Error, r.Combined())
 
-	expected := sqlOutput(`+---------------+
+	expected := `+---------------+
 | repository_id |
 +---------------+
 | repo_a        |
 +---------------+
-`)
+`
 	require.Contains(r.Stdout(), expected)
 
 	// Daemon is running, calling init with a different workdir should
@@ -206,15 +207,51 @@ func (s *InitTestSuite) TestChangeWorkdir() {
 	r = s.RunCommand("sql", "select * from repositories")
 	require.NoError(r.Error, r.Combined())
 
-	expected = sqlOutput(`+---------------+
+	expected = `+---------------+
 | repository_id |
 +---------------+
 | repo_b        |
 +---------------+
-`)
+`
 	require.Contains(r.Stdout(), expected)
 }
 
+func (s *InitTestSuite) TestChangeWorkdirImageOverride() {
+	require := s.Require()
+
+	workdirA := filepath.Join(s.TestDir, "workdir_a")
+	workdirB := filepath.Join(s.TestDir, "workdir_b")
+	s.initGitRepo(filepath.Join(workdirA, "repo_a"))
+	s.initGitRepo(filepath.Join(workdirB, "repo_b"))
+
+	// gitbase runs an image different from the default one
+	configFile := filepath.Join(s.TestDir, "config.yml")
+	err := ioutil.WriteFile(configFile, []byte(`components:
+  gitbase:
+    port: 3316
+    version: latest
+  daemon:
+    port: 4252
+`), 0644)
+	require.NoError(err)
+
+	r := s.RunCommand("init", workdirA, "--config", configFile)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "select * from repositories")
+	require.NoError(r.Error, r.Combined())
+	require.Contains(r.Stdout(), "repo_a")
+
+	// the gitbase container of workdir A is replaced
+	r = s.RunCommand("init", workdirB, "--config", configFile)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "select * from repositories")
+	require.NoError(r.Error, r.Combined())
+	require.Contains(r.Stdout(), "repo_b")
+	require.NotContains(r.Stdout(), "repo_a")
+}
+
 func (s *InitTestSuite) TestRefreshWorkdir() {
 	require := s.Require()
 
@@ -232,12 +269,12 @@ func (s *InitTestSuite) TestRefreshWorkdir() {
 	r = s.RunCommand("sql", "select * from repositories")
 	require.NoError(r.Error, r.Combined())
 
-	expected := sqlOutput(`+---------------+
+	expected := `+---------------+
 | repository_id |
 +---------------+
 | repo_a        |
 +---------------+
-`)
+`
 	require.Contains(r.Stdout(), expected)
 
 	// Init the second git repo
@@ -251,12 +288,12 @@ func (s *InitTestSuite) TestRefreshWorkdir() {
 	r = s.RunCommand("sql", "select * from repositories order by repository_id")
 	require.NoError(r.Error, r.Combined())
 
-	expected = sqlOutput(`+---------------+
+	expected = `+---------------+
 | repository_id |
 +---------------+
 | repo_a        |
 | repo_b        |
 +---------------+
-`)
+`
 	require.Contains(r.Stdout(), expected)
 }
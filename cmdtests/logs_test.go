@@ -0,0 +1,51 @@
+// +build integration
+
+package cmdtests_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/src-d/engine/cmdtests"
+	"github.com/stretchr/testify/suite"
+)
+
+type LogsTestSuite struct {
+	cmdtests.IntegrationTmpDirSuite
+}
+
+func TestLogsTestSuite(t *testing.T) {
+	s := LogsTestSuite{IntegrationTmpDirSuite: cmdtests.NewIntegrationTmpDirSuite()}
+	suite.Run(t, &s)
+}
+
+func (s *LogsTestSuite) TestLogs() {
+	require := s.Require()
+
+	r := s.RunInit(s.TestDir)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "SELECT 1")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("logs", "gitbase")
+	require.NoError(r.Error, r.Combined())
+	require.NotEmpty(r.Combined())
+
+	r = s.RunCommand("logs", "daemon", "--tail", "2")
+	require.NoError(r.Error, r.Combined())
+	lines := strings.Split(strings.TrimSpace(r.Combined()), "\n")
+	require.Len(lines, 2)
+
+	r = s.RunCommand("logs", "daemon", "--tail", "0")
+	require.NoError(r.Error, r.Combined())
+	require.Empty(r.Stdout())
+
+	r = s.RunCommand("logs", "gitbase-web")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "gitbase-web has no container")
+
+	r = s.RunCommand("logs", "unknown")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "unknown component unknown")
+}
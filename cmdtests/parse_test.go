@@ -3,14 +3,18 @@
 package cmdtests_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/src-d/engine/cmdtests"
+	"github.com/src-d/engine/docker"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -47,7 +51,7 @@ var testCases = []testCase{
 		{
 			path:     filepath.FromSlash("testdata/hello.cpp"),
 			filename: "hello.cpp",
-			lang:     "c++",
+			lang:     "cpp",
 		},
 	*/
 	{
@@ -63,7 +67,7 @@ var testCases = []testCase{
 	{
 		path:     filepath.FromSlash("testdata/hello.bash"),
 		filename: "hello.bash",
-		lang:     "shell",
+		lang:     "bash",
 	},
 	{
 		path:     filepath.FromSlash("testdata/hello.rb"),
@@ -80,7 +84,7 @@ var testCases = []testCase{
 		{
 			path:     filepath.FromSlash("testdata/hello.cs"),
 			filename: "hello.cs",
-			lang:     "c#",
+			lang:     "csharp",
 		},
 	*/
 	{
@@ -117,6 +121,159 @@ func (s *ParseTestSuite) TestDriversList() {
 	require.Regexp(expected, r.Stdout())
 }
 
+func (s *ParseTestSuite) TestDriversStatus() {
+	require := s.Require()
+
+	// parse a file first to make sure there is a running driver instance
+	r := s.RunCommand("parse", "uast", filepath.FromSlash("testdata/hello.py"))
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("parse", "drivers", "--status")
+	require.NoError(r.Error, r.Combined())
+
+	expected := regexp.MustCompile(`LANGUAGE\s+VERSION\s+WANTED\s+RUNNING\s+WAITING\s+SUCCESS\s+ERRORS\s+EXITED\s+LAST ERROR`)
+	require.Regexp(expected, r.Stdout())
+	expected = regexp.MustCompile(`python\s+v\S+\s+\d+\s+[1-9]\d*\s+\d+\s+[1-9]\d*`)
+	require.Regexp(expected, r.Stdout())
+	expected = regexp.MustCompile(`INSTANCE\s+LANGUAGE\s+IMAGE\s+STATUS\s+CREATED\s+PROCESSES`)
+	require.Regexp(expected, r.Stdout())
+}
+
+func (s *ParseTestSuite) TestDriversInstallRemove() {
+	require := s.Require()
+
+	rubyDriver := regexp.MustCompile(`ruby\s+v\S+`)
+
+	r := s.RunCommand("parse", "drivers", "remove", "ruby")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("parse", "drivers")
+	require.NoError(r.Error, r.Combined())
+	require.NotRegexp(rubyDriver, r.Stdout())
+
+	r = s.RunCommand("parse", "drivers", "install", "ruby")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("parse", "drivers")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(rubyDriver, r.Stdout())
+
+	r = s.RunCommand("parse", "drivers", "install", "ruby")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "driver for ruby is already installed")
+
+	r = s.RunCommand("parse", "drivers", "install", "--update", "ruby", "bblfsh/ruby-driver:latest")
+	require.NoError(r.Error, r.Combined())
+}
+
+func (s *ParseTestSuite) TestDriversPersistence() {
+	require := s.Require()
+
+	rubyDriver := regexp.MustCompile(`ruby\s+v\S+`)
+
+	r := s.RunCommand("parse", "drivers", "remove", "ruby")
+	require.NoError(r.Error, r.Combined())
+
+	defer func() {
+		r := s.RunCommand("parse", "drivers", "install", "ruby")
+		s.NoError(r.Error, r.Combined())
+	}()
+
+	// stop removes the bblfshd container, the drivers must be kept in its volume
+	r = s.RunCommand("stop")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("parse", "drivers")
+	require.NoError(r.Error, r.Combined())
+	require.NotRegexp(rubyDriver, r.Stdout())
+
+	r = s.RunCommand("components", "list")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(regexp.MustCompile(`srcd-cli-bblfshd-\S+ +srcd-cli-bblfshd\n`), r.Stdout())
+}
+
+func (s *ParseTestSuite) TestOldDriversVolumeRemoved() {
+	require := s.Require()
+
+	oldVolume := "srcd-cli-bblfshd-v0.0.1"
+	require.NoError(docker.CreateVolume(context.Background(), oldVolume, "srcd-cli-bblfshd"))
+
+	// the volume of the new version is used when bblfshd is created again
+	r := s.RunCommand("stop")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("parse", "drivers")
+	require.NoError(r.Error, r.Combined())
+
+	vols, err := docker.ListOwnedVolumes(context.Background())
+	require.NoError(err)
+	for _, v := range vols {
+		require.NotEqual(oldVolume, v.Name)
+	}
+}
+
+func (s *ParseTestSuite) TestUastDirectory() {
+	require := s.Require()
+
+	dir, err := ioutil.TempDir("", "srcd-parse-uast")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	sub := filepath.Join(dir, "sub")
+	require.NoError(os.MkdirAll(sub, 0755))
+	for _, name := range []string{"hello.py", "hello.go", "README.md"} {
+		b, err := ioutil.ReadFile(filepath.Join("testdata", name))
+		require.NoError(err)
+		require.NoError(ioutil.WriteFile(filepath.Join(sub, name), b, 0644))
+	}
+
+	r := s.RunCommand("parse", "uast", dir)
+	require.NoError(r.Error, r.Combined())
+
+	// README.md is skipped, markdown is not supported
+	langs := s.parseUASTLines(r.Stdout())
+	require.Equal(map[string]string{
+		filepath.Join(dir, "sub", "hello.py"): "python",
+		filepath.Join(dir, "sub", "hello.go"): "go",
+	}, langs)
+}
+
+func (s *ParseTestSuite) TestUastGlob() {
+	require := s.Require()
+
+	r := s.RunCommand("parse", "uast", "--parallelism", "2", filepath.FromSlash("testdata/*.py"))
+	require.NoError(r.Error, r.Combined())
+
+	langs := s.parseUASTLines(r.Stdout())
+	require.Equal(map[string]string{
+		filepath.FromSlash("testdata/hello.py"):     "python",
+		filepath.FromSlash("testdata/hello-py3.py"): "python",
+	}, langs)
+}
+
+// parseUASTLines checks each line of the output is a valid parse result and
+// returns the language of each path
+func (s *ParseTestSuite) parseUASTLines(out string) map[string]string {
+	require := s.Require()
+
+	langs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var res struct {
+			Path  string
+			Lang  string
+			UAST  []interface{}
+			Error string
+		}
+		require.NoError(json.Unmarshal([]byte(line), &res), line)
+		require.Empty(res.Error)
+		require.NotEmpty(res.UAST)
+
+		langs[res.Path] = res.Lang
+	}
+
+	return langs
+}
+
 func (s *ParseTestSuite) TestLang() {
 	for _, tc := range testCases {
 		s.T().Run(tc.filename, func(t *testing.T) {
@@ -180,16 +337,6 @@ func (s *ParseTestSuite) TestUast() {
 				// we need to read stdout only
 				r := s.RunCommand("parse", args...)
 
-				// ----------------
-				// TODO Temporary test skip, it fails for cpp, bash, and csharp.
-				// See https://github.com/src-d/engine/issues/297
-				if tc.lang == "shell" {
-					// This Error assertion will fail when #297 is fixed, to remind us to remove this skip
-					require.Error(r.Error)
-					t.Skip("TEST FAILURE IS A KNOWN ISSUE (#297): " + r.Stdout())
-				}
-				// ----------------
-
 				extraInfo := fmt.Sprintf("srcd parse %s\n%s", strings.Join(args, " "), r.Combined())
 				require.NoError(r.Error, extraInfo)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/src-d/engine/docker"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -56,15 +57,13 @@ func (s *PruneTestSuite) testRunningContainers(require *require.Assertions, with
 	// Test containers were deleted
 	s.AllStopped()
 
-	// Test volumes with name srcd-cli-* were deleted.
-	// The logic used in prune to delete named volumes is looking for the
-	// srcd-cli- prefix in the name, so that's what we check here.
-	vols, err := docker.ListVolumes(context.Background())
+	// Test the volumes labeled by the engine were deleted
+	owned, err := docker.ListOwnedVolumes(context.Background())
 	require.NoError(err)
+	require.Empty(volNames(owned))
 
-	for _, vol := range vols {
-		require.NotContainsf(vol.Name, "srcd-cli-", "Volume %s was not deleted", vol.Name)
-	}
+	vols, err := docker.ListVolumes(context.Background())
+	require.NoError(err)
 
 	// Test anonymous volumes were deleted
 	require.Equal(volNames(prevVols), volNames(vols))
@@ -92,6 +91,90 @@ func (s *PruneTestSuite) TestStoppedContainers() {
 	require.NoError(r.Error, r.Combined())
 }
 
+func (s *PruneTestSuite) TestForeignContainer() {
+	require := s.Require()
+
+	c, err := docker.GetClient()
+	require.NoError(err)
+
+	image := components.MysqlCli
+	require.NoError(docker.EnsureInstalled(image.Image, image.Version))
+
+	// a container with the engine prefix but without its labels
+	name := "srcd-cli-not-from-engine"
+	res, err := c.ContainerCreate(context.Background(), &container.Config{
+		Image: image.ImageWithVersion(),
+	}, nil, nil, name)
+	require.NoError(err)
+	defer c.ContainerRemove(context.Background(), res.ID, types.ContainerRemoveOptions{Force: true})
+
+	// a container of the engine of another user
+	otherName := "srcd-cli-of-someone-else"
+	other, err := c.ContainerCreate(context.Background(), &container.Config{
+		Image: image.ImageWithVersion(),
+		Labels: map[string]string{
+			docker.LabelComponent: otherName,
+			docker.LabelUser:      "someone-else",
+		},
+	}, nil, nil, otherName)
+	require.NoError(err)
+	defer c.ContainerRemove(context.Background(), other.ID, types.ContainerRemoveOptions{Force: true})
+
+	r := s.RunCommand("prune")
+	require.NoError(r.Error, r.Combined())
+
+	_, err = c.ContainerInspect(context.Background(), res.ID)
+	require.NoError(err, "the container was removed by prune")
+
+	_, err = c.ContainerInspect(context.Background(), other.ID)
+	require.NoError(err, "the container of another user was removed by prune")
+}
+
+func (s *PruneTestSuite) TestLegacyContainer() {
+	require := s.Require()
+
+	c, err := docker.GetClient()
+	require.NoError(err)
+
+	image := components.MysqlCli
+	require.NoError(docker.EnsureInstalled(image.Image, image.Version))
+
+	// a gitbase container created by a previous version, without labels
+	createLegacy := func(name string) string {
+		res, err := c.ContainerCreate(context.Background(), &container.Config{
+			Image: image.ImageWithVersion(),
+		}, nil, nil, name)
+		require.NoError(err)
+		return res.ID
+	}
+
+	id := createLegacy(components.Gitbase.Name)
+	defer c.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{Force: true})
+
+	// it is replaced instead of causing a name conflict
+	r := s.RunInit(s.TestDir)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "SELECT 1")
+	require.NoError(r.Error, r.Combined())
+
+	_, err = c.ContainerInspect(context.Background(), id)
+	require.Error(err, "the legacy gitbase container was not replaced")
+
+	// and removed by prune
+	r = s.RunCommand("stop")
+	require.NoError(r.Error, r.Combined())
+
+	id = createLegacy(components.Gitbase.Name)
+	defer c.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{Force: true})
+
+	r = s.RunCommand("prune")
+	require.NoError(r.Error, r.Combined())
+
+	_, err = c.ContainerInspect(context.Background(), id)
+	require.Error(err, "the legacy gitbase container was not removed by prune")
+}
+
 func (s *PruneTestSuite) TestRunningContainersWithImages() {
 	if os.Getenv("TEST_PRUNE_WITH_IMAGE") != "true" {
 		s.T().Skip("Use env var TEST_PRUNE_WITH_IMAGE=true to test srcd prune with --with-images flag")
@@ -23,10 +23,10 @@ func (s *SQLREPLTestSuite) TestInteractiveREPL() {
 	require.NoError(err)
 
 	res := s.runInteractiveQuery(in, "show tables;\n", out)
-	require.Contains(res, showTablesOutput)
+	require.Contains(res, sqlOutput(showTablesOutput))
 
 	res = s.runInteractiveQuery(in, "describe table repositories;\n", out)
-	require.Contains(res, showRepoTableDescOutput)
+	require.Contains(res, sqlOutput(showRepoTableDescOutput))
 
 	require.NoError(s.exitInteractiveAndWait(10*time.Second, in, out))
 	require.NoError(s.waitMysqlCliContainerStopped(10, 1*time.Second))
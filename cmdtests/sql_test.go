@@ -7,17 +7,20 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/src-d/engine/cmdtests"
+	"github.com/src-d/engine/components"
+	"github.com/src-d/engine/docker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gotest.tools/icmd"
 )
 
-var showTablesOutput = sqlOutput(`+--------------+
+var showTablesOutput = `+--------------+
 | Table        |
 +--------------+
 | blobs        |
@@ -32,14 +35,14 @@ var showTablesOutput = sqlOutput(`+--------------+
 | repositories |
 | tree_entries |
 +--------------+
-`)
+`
 
-var showRepoTableDescOutput = sqlOutput(`+---------------+------+
+var showRepoTableDescOutput = `+---------------+------+
 | name          | type |
 +---------------+------+
 | repository_id | TEXT |
 +---------------+------+
-`)
+`
 
 type SQLREPLTestSuite struct {
 	cmdtests.IntegrationTmpDirSuite
@@ -93,12 +96,12 @@ func (s *SQLTestSuite) TestInit() {
 	r = s.RunCommand("sql", "select * from repositories")
 	require.NoError(r.Error, r.Combined())
 
-	expected := sqlOutput(`+---------------+
+	expected := `+---------------+
 | repository_id |
 +---------------+
 | reponame      |
 +---------------+
-`)
+`
 	require.Contains(r.Stdout(), expected)
 }
 
@@ -146,23 +149,23 @@ func (s *SQLTestSuite) TestWrongQuery() {
 	}{
 		{
 			query: "show",
-			err:   "ERROR 1105 (HY000) at line 1: unknown error: syntax error at position",
+			err:   "syntax error at position",
 		},
 		{
 			query: "select from repositories",
-			err:   "ERROR 1105 (HY000) at line 1: unknown error: syntax error at position",
+			err:   "syntax error at position",
 		},
 		{
 			query: "select * from nope",
-			err:   "ERROR 1105 (HY000) at line 1: unknown error: table not found: nope",
+			err:   "table not found: nope",
 		},
 		{
 			query: "insert into repositories values ('myrepo')",
-			err:   "ERROR 1105 (HY000) at line 1: unknown error: table doesn't support INSERT INTO",
+			err:   "table doesn't support INSERT INTO",
 		},
 		{
 			query: "select nope from repositories",
-			err:   `ERROR 1105 (HY000) at line 1: unknown error: column "nope" could not be found in any table in scope`,
+			err:   `column "nope" could not be found in any table in scope`,
 		},
 	}
 
@@ -173,11 +176,55 @@ func (s *SQLTestSuite) TestWrongQuery() {
 			r := s.RunCommand("sql", tc.query)
 			assert.Error(r.Error)
 
-			assert.Contains(r.Stdout(), tc.err)
+			assert.Contains(r.Stderr(), tc.err)
 		})
 	}
 }
 
+func (s *SQLTestSuite) TestTimeout() {
+	require := s.Require()
+
+	r := s.RunCommand("sql", "--timeout", "1s", "select sleep(10)")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "query timeout exceeded")
+
+	r = s.RunCommand("sql", "--timeout", "-1s", "show tables")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "invalid timeout")
+}
+
+func (s *SQLTestSuite) TestPsAndKill() {
+	require := s.Require()
+
+	// gitbase is not started to list or kill queries
+	r := s.RunCommand("sql", "ps")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(regexp.MustCompile(`ID\s+USER\s+HOST\s+TIME\s+STATE\s+QUERY`), r.Stdout())
+
+	r = s.RunCommand("sql", "kill", "123456")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "there is no running query with id 123456, gitbase is not running")
+
+	running, err := docker.IsRunning(components.Gitbase.Name, "")
+	require.NoError(err)
+	require.False(running)
+
+	r = s.RunCommand("sql", "select 1")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "ps")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(regexp.MustCompile(`ID\s+USER\s+HOST\s+TIME\s+STATE\s+QUERY`), r.Stdout())
+
+	r = s.RunCommand("sql", "kill", "123456")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "there is no running query with id 123456")
+
+	r = s.RunCommand("sql", "kill", "nope")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "invalid query id nope")
+}
+
 func (s *SQLTestSuite) TestIndexesWorkdirChange() {
 	require := s.Require()
 
@@ -239,6 +286,8 @@ func (s *SQLTestSuite) testQueryWithIndex(require *require.Assertions, repo stri
 	require.Contains(r.Stdout(), repo)
 }
 
+// sqlOutput returns the output of the interactive mysql client, that runs in
+// a terminal
 func sqlOutput(v string) string {
 	return strings.Replace(v, "\n", "\r\n", -1)
 }
@@ -0,0 +1,73 @@
+// +build integration
+
+package cmdtests_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/src-d/engine/cmdtests"
+	"github.com/src-d/engine/components"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusTestSuite struct {
+	cmdtests.IntegrationTmpDirSuite
+}
+
+func TestStatusTestSuite(t *testing.T) {
+	s := StatusTestSuite{IntegrationTmpDirSuite: cmdtests.NewIntegrationTmpDirSuite()}
+	suite.Run(t, &s)
+}
+
+func (s *StatusTestSuite) TestTable() {
+	require := s.Require()
+
+	r := s.RunInit(s.TestDir)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("status")
+	require.NoError(r.Error, r.Combined())
+
+	out := r.Stdout()
+	require.Contains(out, "workdir: "+s.TestDir+"\n")
+	require.Regexp(regexp.MustCompile(`(?m)^`+components.Daemon.Name+`\s+\S+\s+running\s+yes\s+\S+`), out)
+	require.Regexp(regexp.MustCompile(`(?m)^`+components.GitbaseWeb.Name+`\s+\S+\s+not created\s+no`), out)
+}
+
+func (s *StatusTestSuite) TestJSON() {
+	require := s.Require()
+
+	r := s.RunInit(s.TestDir)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "SELECT 1")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("status", "--json")
+	require.NoError(r.Error, r.Combined())
+
+	var status struct {
+		Workdir    string
+		Config     string
+		Components []struct {
+			Name  string
+			State string
+			Ready bool
+		}
+	}
+	require.NoError(json.Unmarshal([]byte(r.Stdout()), &status))
+
+	require.Equal(s.TestDir, status.Workdir)
+	require.Contains(status.Config, "host_ip: 127.0.0.1")
+
+	states := make(map[string]bool)
+	for _, c := range status.Components {
+		states[c.Name] = c.State == "running" && c.Ready
+	}
+
+	require.True(states[components.Daemon.Name])
+	require.True(states[components.Gitbase.Name])
+	require.True(states[components.Bblfshd.Name])
+}
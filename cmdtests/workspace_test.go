@@ -0,0 +1,87 @@
+// +build integration
+
+package cmdtests_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/src-d/engine/cmdtests"
+	"github.com/src-d/engine/docker"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkspaceTestSuite struct {
+	cmdtests.IntegrationTmpDirSuite
+}
+
+func TestWorkspaceTestSuite(t *testing.T) {
+	s := WorkspaceTestSuite{IntegrationTmpDirSuite: cmdtests.NewIntegrationTmpDirSuite()}
+	suite.Run(t, &s)
+}
+
+func (s *WorkspaceTestSuite) TestInitListUseRm() {
+	require := s.Require()
+
+	dirA := filepath.Join(s.TestDir, "a")
+	dirB := filepath.Join(s.TestDir, "b")
+	require.NoError(os.Mkdir(dirA, 0755))
+	require.NoError(os.Mkdir(dirB, 0755))
+
+	r := s.RunInit(dirA)
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("init", "--workspace", "other", dirB)
+	require.NoError(r.Error, r.Combined())
+
+	// the daemon of the default workspace keeps running
+	for _, name := range []string{"srcd-cli-daemon", "srcd-cli-daemon_other"} {
+		running, err := docker.IsRunning(name, "")
+		require.NoError(err)
+		require.Truef(running, "%s should be running", name)
+	}
+
+	r = s.RunCommand("workspace", "list")
+	require.NoError(r.Error, r.Combined())
+	require.Regexp(regexp.MustCompile(`(?m)^default\s+`+regexp.QuoteMeta(dirA)+`\s+yes`), r.Stdout())
+	require.Regexp(regexp.MustCompile(`(?m)^other \*\s+`+regexp.QuoteMeta(dirB)+`\s+yes`), r.Stdout())
+
+	r = s.RunCommand("sql", "SELECT 1")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("workspace", "use", "default")
+	require.NoError(r.Error, r.Combined())
+
+	r = s.RunCommand("sql", "SELECT 1")
+	require.NoError(r.Error, r.Combined())
+
+	// each workspace has its own gitbase
+	for _, name := range []string{"srcd-cli-gitbase", "srcd-cli-gitbase_other"} {
+		running, err := docker.IsRunning(name, "")
+		require.NoError(err)
+		require.Truef(running, "%s should be running", name)
+	}
+
+	r = s.RunCommand("workspace", "rm", "default")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "the default workspace can't be removed")
+
+	r = s.RunCommand("workspace", "rm", "other")
+	require.NoError(r.Error, r.Combined())
+
+	for _, name := range []string{"srcd-cli-daemon_other", "srcd-cli-gitbase_other"} {
+		running, err := docker.IsRunning(name, "")
+		require.NoError(err)
+		require.Falsef(running, "%s should not be running", name)
+	}
+
+	running, err := docker.IsRunning("srcd-cli-gitbase", "")
+	require.NoError(err)
+	require.True(running)
+
+	r = s.RunCommand("workspace", "use", "other")
+	require.Error(r.Error)
+	require.Contains(r.Stderr(), "the workspace other does not exist")
+}
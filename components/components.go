@@ -2,6 +2,8 @@ package components
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/src-d/engine/docker"
 
+	"github.com/docker/docker/api/types/mount"
 	"github.com/pkg/errors"
 	"gopkg.in/src-d/go-log.v1"
 )
@@ -16,6 +19,12 @@ import (
 // cli version set by src-d command
 var cliVersion = ""
 
+// custom are the components declared in the config file
+var custom []Component
+
+// workspace is the name of the workspace the components belong to
+var workspace = DefaultWorkspace
+
 // SetCliVersion sets cli version
 func SetCliVersion(v string) {
 	cliVersion = v
@@ -30,6 +39,68 @@ type Component struct {
 	retrieveVersionFunc func(*Component) (string, bool, error)
 }
 
+// Custom returns a component declared in the config file. Its container name
+// is the given name with the same prefix and workspace suffix as the built-in
+// components
+func Custom(name, image, version string) Component {
+	return Component{
+		Name:    ContainerName(name),
+		Image:   image,
+		Version: version,
+	}
+}
+
+// SetWorkspace sets the workspace of the components. The containers of the
+// daemon, gitbase, gitbase-web, mysql-cli and the custom components belong to
+// a workspace, bblfshd and bblfsh-web are shared by all of them. It must be
+// called before SetCustom.
+func SetWorkspace(name string) {
+	workspace = name
+	Daemon.Name = ContainerName("daemon")
+	Gitbase.Name = ContainerName("gitbase")
+	GitbaseWeb.Name = ContainerName("gitbase-web")
+	MysqlCli.Name = ContainerName("mysql-cli")
+}
+
+// Workspace returns the workspace set with SetWorkspace
+func Workspace() string {
+	return workspace
+}
+
+// ContainerName returns the container name of the component with the given
+// short name in the current workspace
+func ContainerName(name string) string {
+	return WorkspaceContainerName(workspace, name)
+}
+
+// WorkspaceContainerName returns the container name of the component with
+// the given short name in a workspace, with the workspace after
+// WorkspaceSeparator. The containers of the default workspace have no suffix.
+func WorkspaceContainerName(ws, name string) string {
+	if ws == DefaultWorkspace {
+		return ContainerPrefix + name
+	}
+
+	return ContainerPrefix + name + WorkspaceSeparator + ws
+}
+
+// ShortName returns the name of a component used in the CLI, its container
+// name without the prefix and the suffix of the current workspace
+func ShortName(containerName string) string {
+	name := strings.TrimPrefix(containerName, ContainerPrefix)
+	if workspace != DefaultWorkspace {
+		name = strings.TrimSuffix(name, WorkspaceSeparator+workspace)
+	}
+
+	return name
+}
+
+// SetCustom sets the components declared in the config file, that are
+// returned by List along with the built-in ones
+func SetCustom(cs []Component) {
+	custom = cs
+}
+
 func (c *Component) ImageWithVersion() string {
 	return fmt.Sprintf("%s:%s", c.Image, c.Version)
 }
@@ -75,6 +146,38 @@ func (c *Component) GetPorts() ([]docker.Port, error) {
 	return info.Ports, nil
 }
 
+// GetVolumes returns the names of the docker volumes mounted by the component
+// container if there is any
+func (c *Component) GetVolumes() ([]string, error) {
+	info, err := docker.Info(c.Name)
+	if err == docker.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []string
+	for _, m := range info.Mounts {
+		if m.Type == mount.TypeVolume && m.Name != "" {
+			volumes = append(volumes, m.Name)
+		}
+	}
+
+	return volumes, nil
+}
+
+// GetLimits returns the resource limits applied to the component container,
+// a zero value if there is no container
+func (c *Component) GetLimits() (docker.Limits, error) {
+	l, err := docker.GetLimits(c.Name)
+	if err == docker.ErrNotFound {
+		return docker.Limits{}, nil
+	}
+
+	return l, err
+}
+
 // RetrieveVersion updates the Version field with a compatible tag for the
 // image based on the current fixed version; it returns true if there are any
 // newer versions with breaking changes
@@ -133,15 +236,23 @@ var (
 		// Version
 		retrieveVersionFunc: daemonRetrieveVersion,
 	}
-
-	workDirDependants = []Component{
-		Daemon,
-		Gitbase,
-		Bblfshd, // does not depend on workdir but it does depend on user dir
-	}
 )
 
 const (
+	// ContainerPrefix is the prefix of the names of the containers and
+	// volumes created by the engine. The objects that belong to the engine
+	// are found by their docker labels, not by this prefix
+	ContainerPrefix = "srcd-cli-"
+
+	// DefaultWorkspace is the name of the workspace used when none is
+	// selected
+	DefaultWorkspace = "default"
+
+	// WorkspaceSeparator separates the component name and the workspace in
+	// the container names. The names of the components and the workspaces
+	// can't contain it, so a container name has only one meaning.
+	WorkspaceSeparator = "_"
+
 	// BblfshParsePort is the Bblfsh private port for parse requests
 	BblfshParsePort = 9432
 	// BblfshControlPort is the Bblfsh private port for control requests
@@ -186,10 +297,17 @@ func filter(cmps []Component, filters []FilterFunc) ([]Component, error) {
 }
 
 // IsWorkingDirDependant is a FilterFunc that filters Components that depend on
-// the working directory.
+// the working directory. They are matched by container name, so the images
+// and versions set in the config file don't matter.
 func IsWorkingDirDependant(cmp Component) (bool, error) {
-	for _, c := range workDirDependants {
-		if c.Image == cmp.Image {
+	workDirDependants := []string{
+		Daemon.Name,
+		Gitbase.Name,
+		Bblfshd.Name, // does not depend on workdir but it does depend on user dir
+	}
+
+	for _, name := range workDirDependants {
+		if name == cmp.Name {
 			return true, nil
 		}
 	}
@@ -213,6 +331,17 @@ func IsRunning(cmp Component) (bool, error) {
 	return r, nil
 }
 
+// IsContainerRunning is a FilterFunc that filters Components that have a
+// container running, using any image
+func IsContainerRunning(cmp Component) (bool, error) {
+	r, err := docker.IsRunning(cmp.Name, "")
+	if err != nil {
+		return false, nil
+	}
+
+	return r, nil
+}
+
 // List returns the list of known Components, which may or may not be installed.
 // If allVersions is true other Components with image versions different from
 // the current ones will be included.
@@ -225,6 +354,7 @@ func List(ctx context.Context, allVersions bool, filters ...FilterFunc) ([]Compo
 		Bblfshd,
 		BblfshWeb,
 	}
+	componentsList = append(componentsList, custom...)
 
 	if allVersions {
 		otherComponents := make([]Component, 0)
@@ -321,12 +451,10 @@ func removeContainers() error {
 		}
 
 		name := strings.TrimLeft(c.Names[0], "/")
-		if isFromEngine(name) {
-			log.Infof("removing container %s", name)
+		log.Infof("removing container %s", name)
 
-			if err := docker.RemoveContainer(name); err != nil {
-				return err
-			}
+		if err := docker.RemoveContainer(name); err != nil {
+			return err
 		}
 	}
 
@@ -334,18 +462,16 @@ func removeContainers() error {
 }
 
 func removeVolumes() error {
-	vols, err := docker.ListVolumes(context.Background())
+	vols, err := docker.ListOwnedVolumes(context.Background())
 	if err != nil {
 		return err
 	}
 
 	for _, vol := range vols {
-		if isFromEngine(vol.Name) {
-			log.Infof("removing volume %s", vol.Name)
+		log.Infof("removing volume %s", vol.Name)
 
-			if err := docker.RemoveVolume(context.Background(), vol.Name); err != nil {
-				return err
-			}
+		if err := docker.RemoveVolume(context.Background(), vol.Name); err != nil {
+			return err
 		}
 	}
 
@@ -371,6 +497,9 @@ func removeImages() error {
 	return nil
 }
 
-func isFromEngine(name string) bool {
-	return strings.HasPrefix(name, "srcd-cli-")
+// WorkdirHash returns the hash that identifies a working directory of the
+// daemon in the names and labels of the docker objects
+func WorkdirHash(workdir string) string {
+	h := sha1.Sum([]byte(workdir))
+	return hex.EncodeToString(h[:])
 }
@@ -0,0 +1,63 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWorkingDirDependant(t *testing.T) {
+	require := require.New(t)
+
+	// the images of the config file don't change the components
+	gitbase := Gitbase
+	gitbase.Image = "myorg/gitbase"
+	gitbase.Version = "v0.20.0-patched"
+
+	for _, c := range []Component{Daemon, gitbase, Bblfshd} {
+		ok, err := IsWorkingDirDependant(c)
+		require.NoError(err)
+		require.True(ok, c.Name)
+	}
+
+	for _, c := range []Component{GitbaseWeb, BblfshWeb, MysqlCli, Custom("analysis", "srcd/gitbase", "")} {
+		ok, err := IsWorkingDirDependant(c)
+		require.NoError(err)
+		require.False(ok, c.Name)
+	}
+}
+
+func TestWorkspaceContainerName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("srcd-cli-gitbase", WorkspaceContainerName(DefaultWorkspace, "gitbase"))
+	require.Equal("srcd-cli-gitbase_web", WorkspaceContainerName("web", "gitbase"))
+	require.Equal("srcd-cli-gitbase-web_repo", WorkspaceContainerName("repo", "gitbase-web"))
+
+	// the gitbase of a workspace named web is not gitbase-web
+	require.NotEqual(
+		WorkspaceContainerName(DefaultWorkspace, "gitbase-web"),
+		WorkspaceContainerName("web", "gitbase"),
+	)
+}
+
+func TestShortName(t *testing.T) {
+	require := require.New(t)
+
+	defer SetWorkspace(DefaultWorkspace)
+
+	require.Equal("gitbase", ShortName("srcd-cli-gitbase"))
+	require.Equal("bblfsh-web", ShortName("srcd-cli-bblfsh-web"))
+
+	SetWorkspace("web")
+	require.Equal("srcd-cli-gitbase_web", Gitbase.Name)
+	require.Equal("srcd-cli-gitbase-web_web", GitbaseWeb.Name)
+	require.Equal("srcd-cli-bblfsh-web", BblfshWeb.Name)
+	require.Equal("srcd-cli-analysis_web", Custom("analysis", "a", "").Name)
+
+	require.Equal("gitbase", ShortName(Gitbase.Name))
+	require.Equal("gitbase-web", ShortName(GitbaseWeb.Name))
+	// the shared components keep their names
+	require.Equal("bblfsh-web", ShortName(BblfshWeb.Name))
+	require.Equal("bblfshd", ShortName(Bblfshd.Name))
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	gosignal "os/signal"
 	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,16 +29,51 @@ import (
 
 type Port = types.Port
 
+var (
+	// host is the docker daemon to connect to. If it is empty the one set in
+	// the environment is used
+	host string
+	// hostErr is returned by GetClient instead of a client, when there is no
+	// docker daemon to connect to
+	hostErr error
+)
+
+// SetHost sets the address of the docker daemon the client connects to,
+// overriding the DOCKER_HOST environment variable. An empty host restores the
+// default behavior
+func SetHost(h string) {
+	host = h
+	hostErr = nil
+}
+
+// SetUnavailable makes GetClient return the given error, for the cases where
+// there is no docker daemon that can be used
+func SetUnavailable(err error) {
+	host = ""
+	hostErr = err
+}
+
 // GetClient returns a docker client if all checks pass.
 // This function performs three checks:
 //   1. checks that docker is installed and running properly,
 //   2. checks that the user is not running docker toolbox.
 //   3. checks that the client api version is supported by the docker engine,
 func GetClient() (*client.Client, error) {
-	log.Debugf("Creating docker client from env")
+	if hostErr != nil {
+		return nil, hostErr
+	}
+
+	opts := []func(*client.Client) error{client.FromEnv}
+	if host != "" {
+		log.Debugf("Creating docker client for host %s", host)
+		opts = append(opts, client.WithHost(host))
+	} else {
+		log.Debugf("Creating docker client from env")
+	}
+
 	// This will fail in case of bad response from the daemon or in
 	// case of docker not installed/running
-	c, err := client.NewClientWithOpts(client.FromEnv)
+	c, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +121,8 @@ var ErrNotFound = errors.New("container not found")
 
 type Container = types.Container
 
+// Info returns the container with the given name, if it was created by the
+// engine of the current owner, or by a previous engine version without labels
 func Info(name string) (*Container, error) {
 	c, err := GetClient()
 	if err != nil {
@@ -107,7 +145,7 @@ func Info(name string) (*Container, error) {
 
 	for _, c := range cs {
 		for _, n := range c.Names {
-			if name == n[1:] {
+			if name == n[1:] && owner.owns(name, c.Labels) {
 				return &c, nil
 			}
 		}
@@ -115,13 +153,55 @@ func Info(name string) (*Container, error) {
 	return nil, ErrNotFound
 }
 
+// IPAddress returns the address of the container with the given name in the
+// engine network
+func IPAddress(name string) (string, error) {
+	info, err := Info(name)
+	if err != nil {
+		return "", err
+	}
+
+	if info.NetworkSettings != nil {
+		n, ok := info.NetworkSettings.Networks[NetworkName]
+		if ok && n.IPAddress != "" {
+			return n.IPAddress, nil
+		}
+	}
+
+	return "", fmt.Errorf("container %s is not connected to %s", name, NetworkName)
+}
+
+// List returns the containers created by the engine of the current owner,
+// and the ones created by previous engine versions without labels
 func List() ([]Container, error) {
 	c, err := GetClient()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create docker client")
 	}
 
-	return c.ContainerList(context.Background(), types.ContainerListOptions{All: true})
+	cs, err := c.ContainerList(context.Background(), types.ContainerListOptions{
+		All:     true,
+		Filters: owner.filters(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	legacy, err := c.ContainerList(context.Background(), types.ContainerListOptions{
+		All:     true,
+		Filters: legacyFilters(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lc := range legacy {
+		if len(lc.Names) > 0 && isLegacy(lc.Names[0][1:], lc.Labels) {
+			cs = append(cs, lc)
+		}
+	}
+
+	return cs, nil
 }
 
 // IsRunning returns true if the container with the given name is running. If
@@ -175,10 +255,16 @@ func RemoveContainer(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	return c.ContainerRemove(ctx, info.ID, types.ContainerRemoveOptions{
+	err = c.ContainerRemove(ctx, info.ID, types.ContainerRemoveOptions{
 		Force:         true,
 		RemoveVolumes: true,
 	})
+	if err != nil {
+		return err
+	}
+
+	emit(Event{Type: EventRemoved, Container: name, Image: info.Image})
+	return nil
 }
 
 // IsInstalled checks whether an image is installed or not. If version is
@@ -250,14 +336,21 @@ func Pull(ctx context.Context, image, version string) error {
 	defer cancel()
 
 	id := image + ":" + version
+	emit(Event{Type: EventPulling, Image: id})
+
 	rc, err := c.ImagePull(ctx, id, types.ImagePullOptions{})
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("could not pull image %q", id))
 	}
 
-	io.Copy(ioutil.Discard, rc)
+	defer rc.Close()
 
-	return rc.Close()
+	if err := readPullProgress(rc, id, pullProgressInterval); err != nil {
+		return err
+	}
+
+	emit(Event{Type: EventPulled, Image: id})
+	return nil
 }
 
 // EnsureInstalled checks whether an image is installed or not. If version is
@@ -321,16 +414,44 @@ func HostPath(hostPath string) (string, error) {
 
 type ConfigOption func(*container.Config, *container.HostConfig)
 
+// WithEnv sets an environment variable, replacing any previous value
 func WithEnv(key, value string) ConfigOption {
 	return func(cfg *container.Config, hc *container.HostConfig) {
+		for i, e := range cfg.Env {
+			if strings.HasPrefix(e, key+"=") {
+				cfg.Env[i] = key + "=" + value
+				return
+			}
+		}
+
 		cfg.Env = append(cfg.Env, key+"="+value)
 	}
 }
 
+// WithEnvMap sets the environment variables of env, like WithEnv
+func WithEnvMap(env map[string]string) ConfigOption {
+	return func(cfg *container.Config, hc *container.HostConfig) {
+		keys := make([]string, 0, len(env))
+		for k := range env {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			WithEnv(k, env[k])(cfg, hc)
+		}
+	}
+}
+
 func WithVolume(name, containerPath, hostOS string) ConfigOption {
 	return withVolume(mount.TypeVolume, name, containerPath, false, hostOS)
 }
 
+func WithROVolume(name, containerPath, hostOS string) ConfigOption {
+	return withVolume(mount.TypeVolume, name, containerPath, true, hostOS)
+}
+
 func WithSharedDirectory(hostPath, containerPath, hostOS string) ConfigOption {
 	return withVolume(mount.TypeBind, hostPath, containerPath, false, hostOS)
 }
@@ -354,9 +475,10 @@ func withVolume(typ mount.Type, hostPath, containerPath string, readOnly bool, h
 	}
 }
 
-// WithPort adds a port binding. If publicPort is 0 it means the port will be
-// chosen by docker, if it is -1 it will be the same one as privatePort
-func WithPort(publicPort, privatePort int) ConfigOption {
+// WithPort adds a port binding on the hostIP address, all the host addresses
+// if it is empty. If publicPort is 0 it means the port will be chosen by
+// docker, if it is -1 it will be the same one as privatePort
+func WithPort(hostIP string, publicPort, privatePort int) ConfigOption {
 	return func(cfg *container.Config, hc *container.HostConfig) {
 		if cfg.ExposedPorts == nil {
 			cfg.ExposedPorts = make(nat.PortSet)
@@ -370,7 +492,7 @@ func WithPort(publicPort, privatePort int) ConfigOption {
 		cfg.ExposedPorts[port] = struct{}{}
 		hc.PortBindings[port] = append(
 			hc.PortBindings[port],
-			nat.PortBinding{HostPort: fmt.Sprint(publicPort)},
+			nat.PortBinding{HostIP: hostIP, HostPort: fmt.Sprint(publicPort)},
 		)
 	}
 }
@@ -418,12 +540,13 @@ func Start(ctx context.Context, config *container.Config, host *container.HostCo
 		return errors.Wrapf(err, "could not create container %s", name)
 	}
 
+	emit(Event{Type: EventCreated, Container: name, Image: config.Image})
+
 	if err := c.ContainerStart(ctx, res.ID, types.ContainerStartOptions{}); err != nil {
 		return errors.Wrapf(err, "could not start container: %s", name)
 	}
 
-	// TODO: remove this hack
-	time.Sleep(time.Second)
+	emit(Event{Type: EventStarted, Container: name, Image: config.Image})
 
 	err = connectToNetwork(ctx, res.ID)
 	return errors.Wrapf(err, "could not connect to network")
@@ -438,6 +561,8 @@ func forceContainerCreate(
 	host *container.HostConfig,
 	name string,
 ) (container.ContainerCreateCreatedBody, error) {
+	withOwnerLabels(config, name)
+
 	res, err := c.ContainerCreate(ctx, config, host, &network.NetworkingConfig{}, name)
 	if err == nil {
 		return res, nil
@@ -463,7 +588,9 @@ func forceContainerCreate(
 	return res, err
 }
 
-func CreateVolume(ctx context.Context, name string) error {
+// CreateVolume creates a volume of the component with the given container
+// name, if it does not exist
+func CreateVolume(ctx context.Context, name, component string) error {
 	c, err := GetClient()
 	if err != nil {
 		return errors.Wrap(err, "could not create docker client")
@@ -474,7 +601,10 @@ func CreateVolume(ctx context.Context, name string) error {
 		return nil
 	}
 
-	_, err = c.VolumeCreate(ctx, volume.VolumeCreateBody{Name: name})
+	_, err = c.VolumeCreate(ctx, volume.VolumeCreateBody{
+		Name:   name,
+		Labels: owner.labels(component),
+	})
 	return err
 }
 
@@ -494,6 +624,34 @@ func ListVolumes(ctx context.Context) ([]*Volume, error) {
 	return list.Volumes, nil
 }
 
+// ListOwnedVolumes returns the volumes created by the engine of the current
+// owner, and the ones created by previous engine versions without labels
+func ListOwnedVolumes(ctx context.Context) ([]*Volume, error) {
+	c, err := GetClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create docker client")
+	}
+
+	list, err := c.VolumeList(ctx, owner.filters())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get list of volumes")
+	}
+
+	legacy, err := c.VolumeList(ctx, legacyFilters())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get list of volumes")
+	}
+
+	vols := list.Volumes
+	for _, v := range legacy.Volumes {
+		if isLegacy(v.Name, v.Labels) {
+			vols = append(vols, v)
+		}
+	}
+
+	return vols, nil
+}
+
 type Image = types.ImageSummary
 
 func ListImages(ctx context.Context) ([]Image, error) {
@@ -557,7 +715,9 @@ func connectToNetwork(ctx context.Context, containerID string) error {
 	if _, err := c.NetworkInspect(ctx, NetworkName, types.NetworkInspectOptions{}); err != nil {
 		log.Debugf("couldn't find network %s: %v", NetworkName, err)
 		log.Infof("creating %s docker network", NetworkName)
-		_, err = c.NetworkCreate(ctx, NetworkName, types.NetworkCreate{})
+		_, err = c.NetworkCreate(ctx, NetworkName, types.NetworkCreate{
+			Labels: owner.labels(NetworkName),
+		})
 		if err != nil {
 			return errors.Wrap(err, "could not create network")
 		}
@@ -579,20 +739,52 @@ func RemoveNetwork(ctx context.Context) error {
 		return errors.Wrap(err, "could not inspect network")
 	}
 
+	if !owner.owns(NetworkName, resp.Labels) {
+		return nil
+	}
+
 	return c.NetworkRemove(ctx, resp.ID)
 }
 
 func GetLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	return Logs(ctx, containerID, LogsOptions{
+		Follow: true,
+		Since:  time.Now().Format(time.RFC3339Nano),
+		Tail:   -1,
+	})
+}
+
+// LogsOptions are the options to read the logs of a container
+type LogsOptions struct {
+	// Follow keeps streaming the new logs
+	Follow bool
+	// Since is a timestamp, or a duration relative to now, of the first logs
+	// to read. All the logs are read if it is empty
+	Since string
+	// Tail is the number of lines to read from the end of the logs. All the
+	// lines are read if it is negative
+	Tail int
+}
+
+// Logs returns the logs of the container. The stdout and stderr streams are
+// multiplexed, they can be split with stdcopy.StdCopy.
+func Logs(ctx context.Context, containerID string, opts LogsOptions) (io.ReadCloser, error) {
 	c, err := GetClient()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create docker client")
 	}
 
+	tail := "all"
+	if opts.Tail >= 0 {
+		tail = strconv.Itoa(opts.Tail)
+	}
+
 	reader, err := c.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
-		Follow:     true,
-		Since:      time.Now().Format(time.RFC3339Nano),
+		Follow:     opts.Follow,
+		Since:      opts.Since,
+		Tail:       tail,
 	})
 
 	return reader, err
@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithEnvMap(t *testing.T) {
+	cfg := &container.Config{Env: []string{"A=1", "B=2"}}
+	WithEnvMap(map[string]string{"C": "3", "A": "4"})(cfg, &container.HostConfig{})
+	require.Equal(t, []string{"A=4", "B=2", "C=3"}, cfg.Env)
+}
+
+func TestWithPort(t *testing.T) {
+	require := require.New(t)
+
+	cfg, hc := &container.Config{}, &container.HostConfig{}
+	WithPort("127.0.0.1", 3307, 3306)(cfg, hc)
+	WithPort("::1", 3307, 3306)(cfg, hc)
+	WithPort("", 8080, 80)(cfg, hc)
+
+	require.Equal(nat.PortSet{"3306": {}, "80": {}}, cfg.ExposedPorts)
+	require.Equal(nat.PortMap{
+		"3306": {
+			{HostIP: "127.0.0.1", HostPort: "3307"},
+			{HostIP: "::1", HostPort: "3307"},
+		},
+		"80": {{HostIP: "", HostPort: "8080"}},
+	}, hc.PortBindings)
+}
@@ -0,0 +1,59 @@
+package docker
+
+import "sync"
+
+// EventType is the kind of change made by this package to a docker image or
+// container
+type EventType string
+
+const (
+	// EventPulling is emitted when an image pull starts
+	EventPulling EventType = "pulling"
+	// EventPullProgress is emitted periodically while an image is pulled
+	EventPullProgress EventType = "pull-progress"
+	// EventPulled is emitted when an image pull finishes
+	EventPulled EventType = "pulled"
+	// EventCreated is emitted when a container is created
+	EventCreated EventType = "created"
+	// EventStarted is emitted when a container is started
+	EventStarted EventType = "started"
+	// EventRemoved is emitted when a container is removed
+	EventRemoved EventType = "removed"
+)
+
+// Event is a change made by this package to a docker image or container
+type Event struct {
+	Type EventType
+	// Container is the name of the container, empty for image events
+	Container string
+	// Image is the image reference, as image:tag
+	Image string
+	// Layers is the progress of the image layers, only for EventPullProgress
+	Layers []LayerProgress
+}
+
+var (
+	eventHandlerMu sync.RWMutex
+	// eventHandler is called with every event, if it is set
+	eventHandler func(Event)
+)
+
+// SetEventHandler sets a function that is called with every change made to
+// the docker images and containers, replacing the previous one. A nil
+// function removes it. It can be called at any time, the events emitted
+// while it is replaced may still be sent to the previous one.
+func SetEventHandler(f func(Event)) {
+	eventHandlerMu.Lock()
+	defer eventHandlerMu.Unlock()
+	eventHandler = f
+}
+
+func emit(e Event) {
+	eventHandlerMu.RLock()
+	f := eventHandler
+	eventHandlerMu.RUnlock()
+
+	if f != nil {
+		f(e)
+	}
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetEventHandlerConcurrent(t *testing.T) {
+	require := require.New(t)
+	defer SetEventHandler(nil)
+
+	var mu sync.Mutex
+	var received int
+	handler := func(Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		received++
+	}
+
+	// the handler is replaced while the events are emitted, like srcd
+	// components install does while the images are pulled
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			emit(Event{Type: EventPullProgress})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			SetEventHandler(handler)
+			SetEventHandler(nil)
+		}
+	}()
+	wg.Wait()
+
+	SetEventHandler(handler)
+	mu.Lock()
+	before := received
+	mu.Unlock()
+
+	emit(Event{Type: EventPulled})
+	mu.Lock()
+	require.Equal(before+1, received)
+	mu.Unlock()
+
+	SetEventHandler(nil)
+	emit(Event{Type: EventPulled})
+	mu.Lock()
+	require.Equal(before+1, received)
+	mu.Unlock()
+}
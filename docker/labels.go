@@ -0,0 +1,188 @@
+package docker
+
+import (
+	"regexp"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// labels set on the containers, volumes and networks created by the engine
+const (
+	// LabelVersion is the version of the engine that created the object
+	LabelVersion = "tech.sourced.engine.version"
+	// LabelComponent is the container name of the component the object
+	// belongs to
+	LabelComponent = "tech.sourced.engine.component"
+	// LabelWorkdir is the hash of the working directory of the daemon
+	LabelWorkdir = "tech.sourced.engine.workdir"
+	// LabelUser is the name of the user that runs the engine
+	LabelUser = "tech.sourced.engine.user"
+	// LabelWorkspace is the workspace the object belongs to, it is not set
+	// on the objects shared by all the workspaces
+	LabelWorkspace = "tech.sourced.engine.workspace"
+)
+
+// Owner identifies the engine that creates the docker objects. Only the
+// objects labeled with the same user are found and removed by this package,
+// unless AnyUser is set.
+type Owner struct {
+	// User is the name of the user that runs the engine
+	User string
+	// AnyUser makes the objects of every user belong to the owner. It is
+	// used for remote engines, their objects are labeled with the user of
+	// the remote host.
+	AnyUser bool
+	// Version is the version of the engine
+	Version string
+	// WorkdirHash is the hash of the working directory of the daemon, it
+	// can be empty if it's not known
+	WorkdirHash string
+	// Workspace is the workspace of the engine, it can be empty if the
+	// objects don't belong to any
+	Workspace string
+	// Shared are the container names of the components shared by all the
+	// workspaces. Their objects, and the network, have no workspace label.
+	Shared []string
+}
+
+var owner Owner
+
+// SetOwner sets the owner of the docker objects created from now on
+func SetOwner(o Owner) {
+	owner = o
+}
+
+// labels returns the labels of an object of the given component
+func (o Owner) labels(component string) map[string]string {
+	labels := map[string]string{
+		LabelVersion:   o.Version,
+		LabelComponent: component,
+		LabelUser:      o.User,
+	}
+
+	if o.WorkdirHash != "" {
+		labels[LabelWorkdir] = o.WorkdirHash
+	}
+
+	if o.Workspace != "" && !o.isShared(component) {
+		labels[LabelWorkspace] = o.Workspace
+	}
+
+	return labels
+}
+
+func (o Owner) isShared(component string) bool {
+	if component == NetworkName {
+		return true
+	}
+
+	for _, name := range o.Shared {
+		if name == component {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filters returns the filters that match the objects of the owner
+func (o Owner) filters() filters.Args {
+	args := filters.NewArgs()
+	args.Add("label", LabelComponent)
+	if !o.AnyUser {
+		args.Add("label", LabelUser+"="+o.User)
+	}
+
+	return args
+}
+
+// legacyPrefix is the name prefix of the objects created by the engine
+// versions that did not label them
+const legacyPrefix = "srcd-cli-"
+
+// legacyNames are the names of the containers of the built-in components,
+// and of the network, created by the engine versions that did not label them
+var legacyNames = []string{
+	"srcd-cli-bblfshd",
+	"srcd-cli-bblfsh-web",
+	"srcd-cli-daemon",
+	"srcd-cli-gitbase",
+	"srcd-cli-gitbase-web",
+	"srcd-cli-mysql-cli",
+	NetworkName,
+}
+
+// legacyVolumeRegexp matches the names of the volumes created by the engine
+// versions that did not label them: the gitbase index of a working directory
+// hash, and the bblfshd drivers storage of a bblfshd version
+var legacyVolumeRegexp = regexp.MustCompile(
+	`^srcd-cli-(gitbase-[0-9a-f]{40}|bblfshd-(latest|v?[0-9]+(\.[0-9]+)*(-[a-z0-9.]+)?))$`)
+
+// isLegacy returns whether an object was created by an engine version that
+// did not label its objects. They are only identified by their exact names,
+// other unlabeled objects with the same prefix are not from the engine.
+func isLegacy(name string, labels map[string]string) bool {
+	if _, ok := labels[LabelComponent]; ok {
+		return false
+	}
+
+	for _, n := range legacyNames {
+		if name == n {
+			return true
+		}
+	}
+
+	return legacyVolumeRegexp.MatchString(name)
+}
+
+// legacyFilters returns the filters that match the objects that may have been
+// created by a previous engine version, by their name. The labeled ones must
+// be discarded with isLegacy.
+func legacyFilters() filters.Args {
+	args := filters.NewArgs()
+	args.Add("name", legacyPrefix)
+	return args
+}
+
+// owns returns whether an object with the given name and labels belongs to
+// the owner. The unlabeled objects of previous engine versions belong to any
+// owner, so they are used and removed like the new ones.
+func (o Owner) owns(name string, labels map[string]string) bool {
+	if isLegacy(name, labels) {
+		return true
+	}
+
+	if _, ok := labels[LabelComponent]; !ok {
+		return false
+	}
+
+	if o.AnyUser {
+		return true
+	}
+
+	user, ok := labels[LabelUser]
+	return ok && user == o.User
+}
+
+// WithLabel sets a label of the container. The owner labels are added when
+// the container is created, unless they are set.
+func WithLabel(key, value string) ConfigOption {
+	return func(cfg *container.Config, hc *container.HostConfig) {
+		if cfg.Labels == nil {
+			cfg.Labels = make(map[string]string)
+		}
+
+		cfg.Labels[key] = value
+	}
+}
+
+// withOwnerLabels adds the labels of the owner that are not set to the config
+// of the named container
+func withOwnerLabels(cfg *container.Config, name string) {
+	for k, v := range owner.labels(name) {
+		if _, ok := cfg.Labels[k]; !ok {
+			WithLabel(k, v)(cfg, nil)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnerLabels(t *testing.T) {
+	require := require.New(t)
+
+	defer SetOwner(Owner{})
+	SetOwner(Owner{User: "alice", Version: "v1.0.0", WorkdirHash: "abc"})
+
+	cfg := &container.Config{}
+	WithLabel(LabelWorkdir, "def")(cfg, nil)
+	withOwnerLabels(cfg, "srcd-cli-gitbase")
+	require.Equal(map[string]string{
+		LabelVersion:   "v1.0.0",
+		LabelComponent: "srcd-cli-gitbase",
+		LabelWorkdir:   "def",
+		LabelUser:      "alice",
+	}, cfg.Labels)
+
+	require.True(owner.owns("srcd-cli-gitbase", cfg.Labels))
+	require.False(Owner{User: "bob"}.owns("srcd-cli-gitbase", cfg.Labels))
+	require.False(owner.owns("gitbase", map[string]string{LabelUser: "alice"}))
+	require.False(owner.owns("gitbase", nil))
+
+	f := owner.filters()
+	require.True(f.ExactMatch("label", LabelUser+"=alice"))
+	require.True(f.ExactMatch("label", LabelComponent))
+
+	// the objects of a remote engine have the user of the remote host
+	remote := Owner{User: "alice", AnyUser: true}
+	require.True(remote.owns("srcd-cli-gitbase", Owner{User: "bob"}.labels("srcd-cli-gitbase")))
+	require.False(remote.owns("gitbase", map[string]string{LabelUser: "bob"}))
+	require.Equal([]string{LabelComponent}, remote.filters().Get("label"))
+	require.True(remote.filters().ExactMatch("label", LabelComponent))
+
+	SetOwner(Owner{User: "alice", Workspace: "repo", Shared: []string{"srcd-cli-bblfshd"}})
+	require.Equal("repo", owner.labels("srcd-cli-gitbase-repo")[LabelWorkspace])
+	require.NotContains(owner.labels("srcd-cli-bblfshd"), LabelWorkspace)
+	require.NotContains(owner.labels(NetworkName), LabelWorkspace)
+}
+
+func TestOwnerLegacy(t *testing.T) {
+	require := require.New(t)
+
+	alice := Owner{User: "alice"}
+
+	// the objects of previous versions have no labels
+	for _, name := range []string{
+		"srcd-cli-gitbase",
+		"srcd-cli-bblfshd",
+		"srcd-cli-daemon",
+		NetworkName,
+		"srcd-cli-gitbase-2aae6c35c94fcfb415dbe95f408b9ce91ee846ed",
+		"srcd-cli-bblfshd-v2.12.1-drivers",
+		"srcd-cli-bblfshd-latest",
+	} {
+		require.True(isLegacy(name, nil), name)
+		require.True(alice.owns(name, map[string]string{"other": "label"}), name)
+	}
+
+	// other unlabeled objects with the prefix are not from the engine
+	for _, name := range []string{
+		"gitbase",
+		"srcd-cli-not-from-engine",
+		"srcd-cli-gitbase-repo",
+		"srcd-cli-gitbase-web-2",
+		"srcd-cli-bblfshd-backup",
+		"srcd-cli-bblfshd-v2.12.1-",
+	} {
+		require.False(isLegacy(name, nil), name)
+		require.False(alice.owns(name, nil), name)
+	}
+
+	// a labeled object is never legacy, whatever its name
+	labels := Owner{User: "bob"}.labels("srcd-cli-gitbase")
+	require.False(isLegacy("srcd-cli-gitbase", labels))
+	require.False(alice.owns("srcd-cli-gitbase", labels))
+
+	require.True(legacyFilters().ExactMatch("name", "srcd-cli-"))
+}
@@ -0,0 +1,131 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+// cpuPeriod is the CFS period used to apply a CPU limit, the --cpus option of
+// docker is a shorthand for it and a quota
+const cpuPeriod = 100000
+
+// Limits are the resource limits of a container. A zero field means no limit.
+type Limits struct {
+	// CPUs is the number of CPUs the container can use, like 1.5
+	CPUs float64
+	// Memory is the maximum memory of the container, in bytes
+	Memory int64
+	// MemorySwap is the maximum memory plus swap of the container, in
+	// bytes, -1 means unlimited swap
+	MemorySwap int64
+	// PidsLimit is the maximum number of processes of the container
+	PidsLimit int64
+}
+
+// IsZero returns whether no limit is set
+func (l Limits) IsZero() bool {
+	return l == Limits{}
+}
+
+// String returns the limits set, like cpus=1.5 memory=2GiB
+func (l Limits) String() string {
+	var parts []string
+	if l.CPUs > 0 {
+		parts = append(parts, fmt.Sprintf("cpus=%g", l.CPUs))
+	}
+
+	if l.Memory > 0 {
+		parts = append(parts, "memory="+units.BytesSize(float64(l.Memory)))
+	}
+
+	switch {
+	case l.MemorySwap > 0:
+		parts = append(parts, "memory_swap="+units.BytesSize(float64(l.MemorySwap)))
+	case l.MemorySwap < 0:
+		parts = append(parts, "memory_swap=unlimited")
+	}
+
+	if l.PidsLimit > 0 {
+		parts = append(parts, fmt.Sprintf("pids_limit=%d", l.PidsLimit))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// WithLimits sets the resource limits of the container. The fields that are
+// zero keep the previous limits.
+func WithLimits(l Limits) ConfigOption {
+	return func(cfg *container.Config, hc *container.HostConfig) {
+		if l.CPUs > 0 {
+			hc.Resources.CPUPeriod = cpuPeriod
+			hc.Resources.CPUQuota = int64(l.CPUs * cpuPeriod)
+		}
+
+		if l.Memory != 0 {
+			hc.Resources.Memory = l.Memory
+		}
+
+		if l.MemorySwap != 0 {
+			hc.Resources.MemorySwap = l.MemorySwap
+		}
+
+		if l.PidsLimit != 0 {
+			hc.Resources.PidsLimit = l.PidsLimit
+		}
+	}
+}
+
+// resourcesLimits returns the limits set in the resources of a container
+func resourcesLimits(r container.Resources) Limits {
+	l := Limits{
+		Memory:     r.Memory,
+		MemorySwap: r.MemorySwap,
+	}
+
+	switch {
+	case r.NanoCPUs > 0:
+		l.CPUs = float64(r.NanoCPUs) / 1e9
+	case r.CPUQuota > 0 && r.CPUPeriod > 0:
+		l.CPUs = float64(r.CPUQuota) / float64(r.CPUPeriod)
+	}
+
+	if r.PidsLimit > 0 {
+		l.PidsLimit = r.PidsLimit
+	}
+
+	return l
+}
+
+// GetLimits returns the resource limits applied to the named container
+func GetLimits(name string) (Limits, error) {
+	info, err := Info(name)
+	if err != nil {
+		return Limits{}, err
+	}
+
+	c, err := GetClient()
+	if err != nil {
+		return Limits{}, errors.Wrap(err, "could not create docker client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	inspect, err := c.ContainerInspect(ctx, info.ID)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return Limits{}, ErrNotFound
+		}
+
+		return Limits{}, errors.Wrapf(err, "could not inspect container %s", name)
+	}
+
+	return resourcesLimits(inspect.HostConfig.Resources), nil
+}
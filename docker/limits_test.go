@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLimits(t *testing.T) {
+	require := require.New(t)
+
+	host := &container.HostConfig{}
+	WithLimits(Limits{CPUs: 2})(&container.Config{}, host)
+	WithLimits(Limits{CPUs: 1.5, Memory: 1 << 30, MemorySwap: -1, PidsLimit: 100})(&container.Config{}, host)
+	require.Equal(int64(100000), host.Resources.CPUPeriod)
+	require.Equal(int64(150000), host.Resources.CPUQuota)
+	require.Equal(int64(1<<30), host.Resources.Memory)
+
+	l := resourcesLimits(host.Resources)
+	require.Equal(Limits{CPUs: 1.5, Memory: 1 << 30, MemorySwap: -1, PidsLimit: 100}, l)
+	require.Equal("cpus=1.5 memory=1GiB memory_swap=unlimited pids_limit=100", l.String())
+
+	// the zero fields keep the previous limits
+	WithLimits(Limits{Memory: 512 << 20})(&container.Config{}, host)
+	require.Equal(int64(150000), host.Resources.CPUQuota)
+	require.Equal("cpus=1.5 memory=512MiB memory_swap=unlimited pids_limit=100",
+		resourcesLimits(host.Resources).String())
+
+	require.True(resourcesLimits(container.Resources{}).IsZero())
+	require.Equal(2.0, resourcesLimits(container.Resources{NanoCPUs: 2e9}).CPUs)
+}
@@ -0,0 +1,150 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// pullProgressInterval is the minimum time between two EventPullProgress of
+// the same image
+const pullProgressInterval = 200 * time.Millisecond
+
+// layer statuses reported by docker during a pull
+const (
+	layerDownloading      = "Downloading"
+	layerVerifying        = "Verifying Checksum"
+	layerDownloadComplete = "Download complete"
+	layerExtracting       = "Extracting"
+	layerPullComplete     = "Pull complete"
+	layerAlreadyExists    = "Already exists"
+)
+
+// LayerProgress is the pull progress of an image layer
+type LayerProgress struct {
+	ID string
+	// Status is the last status reported by docker for the layer, like
+	// Downloading or Extracting
+	Status string
+	// Current is the number of bytes processed by the current status
+	Current int64
+	// Total is the size of the layer, 0 while it is unknown
+	Total int64
+}
+
+// Downloaded returns the number of bytes of the layer already downloaded
+func (l LayerProgress) Downloaded() int64 {
+	switch l.Status {
+	case layerDownloading:
+		return l.Current
+	case layerVerifying, layerDownloadComplete, layerExtracting, layerPullComplete:
+		return l.Total
+	default:
+		return 0
+	}
+}
+
+// Extracted returns the number of bytes of the layer already extracted
+func (l LayerProgress) Extracted() int64 {
+	switch l.Status {
+	case layerExtracting:
+		return l.Current
+	case layerPullComplete:
+		return l.Total
+	default:
+		return 0
+	}
+}
+
+// Done returns whether the layer is ready to be used
+func (l LayerProgress) Done() bool {
+	return l.Status == layerPullComplete || l.Status == layerAlreadyExists
+}
+
+// pullMessage is a message of the JSON stream returned by the image pull
+type pullMessage struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	ProgressDetail struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Error string `json:"error"`
+}
+
+// pullProgress keeps the progress of the layers of an image pull
+type pullProgress struct {
+	order  []string
+	layers map[string]*LayerProgress
+}
+
+func newPullProgress() *pullProgress {
+	return &pullProgress{layers: make(map[string]*LayerProgress)}
+}
+
+// update applies the message to the layer it refers to. It returns false if
+// the message is not about a layer.
+func (p *pullProgress) update(m pullMessage) bool {
+	if m.ID == "" || strings.HasPrefix(m.Status, "Pulling from") {
+		return false
+	}
+
+	l, ok := p.layers[m.ID]
+	if !ok {
+		l = &LayerProgress{ID: m.ID}
+		p.layers[m.ID] = l
+		p.order = append(p.order, m.ID)
+	}
+
+	l.Status = m.Status
+	l.Current = m.ProgressDetail.Current
+	if m.ProgressDetail.Total > 0 {
+		l.Total = m.ProgressDetail.Total
+	}
+
+	return true
+}
+
+// snapshot returns a copy of the progress of the layers, in the order docker
+// reported them
+func (p *pullProgress) snapshot() []LayerProgress {
+	layers := make([]LayerProgress, len(p.order))
+	for i, id := range p.order {
+		layers[i] = *p.layers[id]
+	}
+
+	return layers
+}
+
+// readPullProgress decodes the JSON stream of an image pull, emitting the
+// progress of its layers at most once per interval. It returns the error
+// reported by docker in the stream, if any.
+func readPullProgress(r io.Reader, image string, interval time.Duration) error {
+	p := newPullProgress()
+	dec := json.NewDecoder(r)
+
+	var last time.Time
+	for {
+		var m pullMessage
+		if err := dec.Decode(&m); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		if m.Error != "" {
+			return fmt.Errorf("could not pull image %q: %s", image, m.Error)
+		}
+
+		if !p.update(m) || time.Since(last) < interval {
+			continue
+		}
+
+		last = time.Now()
+		emit(Event{Type: EventPullProgress, Image: image, Layers: p.snapshot()})
+	}
+}
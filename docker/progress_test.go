@@ -0,0 +1,59 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const pullStream = `{"status":"Pulling from srcd/gitbase","id":"v0.19.0"}
+{"status":"Already exists","progressDetail":{},"id":"a1"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"b2"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"c3"}
+{"status":"Downloading","progressDetail":{"current":50,"total":200},"id":"b2"}
+{"status":"Downloading","progressDetail":{"current":10,"total":100},"id":"c3"}
+{"status":"Download complete","progressDetail":{},"id":"b2"}
+{"status":"Extracting","progressDetail":{"current":80,"total":200},"id":"b2"}
+`
+
+func TestReadPullProgress(t *testing.T) {
+	require := require.New(t)
+
+	var events []Event
+	SetEventHandler(func(e Event) { events = append(events, e) })
+	defer SetEventHandler(nil)
+
+	err := readPullProgress(strings.NewReader(pullStream), "srcd/gitbase:v0.19.0", 0)
+	require.NoError(err)
+
+	// the first message is not about a layer
+	require.Len(events, 7)
+
+	last := events[len(events)-1]
+	require.Equal(EventPullProgress, last.Type)
+	require.Equal("srcd/gitbase:v0.19.0", last.Image)
+	require.Equal([]LayerProgress{
+		{ID: "a1", Status: "Already exists"},
+		{ID: "b2", Status: "Extracting", Current: 80, Total: 200},
+		{ID: "c3", Status: "Downloading", Current: 10, Total: 100},
+	}, last.Layers)
+
+	a1, b2, c3 := last.Layers[0], last.Layers[1], last.Layers[2]
+	require.True(a1.Done())
+	require.Equal(int64(200), b2.Downloaded())
+	require.Equal(int64(80), b2.Extracted())
+	require.False(b2.Done())
+	require.Equal(int64(10), c3.Downloaded())
+	require.Equal(int64(0), c3.Extracted())
+}
+
+func TestReadPullProgressError(t *testing.T) {
+	require := require.New(t)
+
+	stream := `{"status":"Pulling from srcd/gitbase","id":"v0.19.0"}
+{"errorDetail":{"message":"unauthorized"},"error":"unauthorized"}
+`
+	err := readPullProgress(strings.NewReader(stream), "srcd/gitbase:v0.19.0", 0)
+	require.EqualError(err, `could not pull image "srcd/gitbase:v0.19.0": unauthorized`)
+}
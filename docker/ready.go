@@ -0,0 +1,102 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// readyInterval is the time between two readiness probes
+	readyInterval = 500 * time.Millisecond
+	// readyProbeTimeout is the time a container has to answer a probe
+	readyProbeTimeout = 2 * time.Second
+)
+
+// ReadyFunc is a readiness probe, it returns an error while the service run
+// by a container can't answer requests
+type ReadyFunc func(ctx context.Context) error
+
+// TCPReady returns a ReadyFunc that succeeds when a TCP connection to addr
+// can be opened
+func TCPReady(addr string) ReadyFunc {
+	return func(ctx context.Context) error {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+}
+
+// NotReadyError is returned by WaitReady when a container does not become
+// ready
+type NotReadyError struct {
+	// Name is the name of the container
+	Name    string
+	Timeout time.Duration
+	// Err is the error of the last probe
+	Err error
+}
+
+func (e *NotReadyError) Error() string {
+	return fmt.Sprintf("%s did not become ready in %s: %s", e.Name, e.Timeout, e.Err)
+}
+
+// WaitReady calls the probe of the named container until it succeeds. It
+// fails if the probe does not succeed before timeout, or as soon as the
+// container stops running.
+func WaitReady(ctx context.Context, name string, probe ReadyFunc, timeout time.Duration) error {
+	return waitReady(ctx, name, probe, timeout, func() (bool, error) {
+		return IsRunning(name, "")
+	})
+}
+
+// waitReady is WaitReady with the function that checks whether the container
+// is running
+func waitReady(
+	ctx context.Context,
+	name string,
+	probe ReadyFunc,
+	timeout time.Duration,
+	isRunning func() (bool, error),
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := callProbe(ctx, probe)
+		if err == nil {
+			return nil
+		}
+
+		running, rerr := isRunning()
+		if rerr != nil {
+			return rerr
+		}
+
+		if !running {
+			return fmt.Errorf("%s stopped before becoming ready, check its logs", name)
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.Canceled {
+				return ctx.Err()
+			}
+
+			return &NotReadyError{Name: name, Timeout: timeout, Err: err}
+		case <-time.After(readyInterval):
+		}
+	}
+}
+
+func callProbe(ctx context.Context, probe ReadyFunc) error {
+	ctx, cancel := context.WithTimeout(ctx, readyProbeTimeout)
+	defer cancel()
+
+	return probe(ctx)
+}
@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPReady(t *testing.T) {
+	require := require.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+
+	probe := TCPReady(l.Addr().String())
+	require.NoError(probe(context.Background()))
+
+	require.NoError(l.Close())
+	require.Error(probe(context.Background()))
+}
+
+func TestNotReadyError(t *testing.T) {
+	err := &NotReadyError{
+		Name:    "srcd-cli-gitbase",
+		Timeout: 5 * time.Minute,
+		Err:     errors.New("connection refused"),
+	}
+
+	require.EqualError(t, err, "srcd-cli-gitbase did not become ready in 5m0s: connection refused")
+}
+
+// fakeProbe fails until it has been called n times
+func fakeProbe(n int32) (ReadyFunc, *int32) {
+	var calls int32
+	return func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) < n {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	}, &calls
+}
+
+func alwaysRunning() (bool, error) { return true, nil }
+
+func TestWaitReady(t *testing.T) {
+	require := require.New(t)
+
+	probe, calls := fakeProbe(3)
+	start := time.Now()
+	err := waitReady(context.Background(), "srcd-cli-gitbase", probe, time.Minute, alwaysRunning)
+	require.NoError(err)
+	require.Equal(int32(3), atomic.LoadInt32(calls))
+	require.True(time.Since(start) >= 2*readyInterval)
+}
+
+func TestWaitReadyTimeout(t *testing.T) {
+	require := require.New(t)
+
+	probe, _ := fakeProbe(100)
+	start := time.Now()
+	err := waitReady(context.Background(), "srcd-cli-gitbase", probe, 100*time.Millisecond, alwaysRunning)
+	require.True(time.Since(start) < readyInterval)
+
+	nerr, ok := err.(*NotReadyError)
+	require.True(ok, "unexpected error %v", err)
+	require.Equal("srcd-cli-gitbase", nerr.Name)
+	require.Equal(100*time.Millisecond, nerr.Timeout)
+	require.EqualError(nerr.Err, "connection refused")
+}
+
+func TestWaitReadyStopped(t *testing.T) {
+	require := require.New(t)
+
+	probe, calls := fakeProbe(100)
+	stopped := func() (bool, error) { return false, nil }
+
+	start := time.Now()
+	err := waitReady(context.Background(), "srcd-cli-gitbase", probe, time.Minute, stopped)
+	require.EqualError(err, "srcd-cli-gitbase stopped before becoming ready, check its logs")
+	require.Equal(int32(1), atomic.LoadInt32(calls))
+	require.True(time.Since(start) < readyInterval)
+
+	failed := func() (bool, error) { return false, errors.New("docker is not running") }
+	err = waitReady(context.Background(), "srcd-cli-gitbase", probe, time.Minute, failed)
+	require.EqualError(err, "docker is not running")
+}
+
+func TestWaitReadyCancel(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	probe, _ := fakeProbe(100)
+	err := waitReady(ctx, "srcd-cli-gitbase", probe, time.Minute, alwaysRunning)
+	require.Equal(context.Canceled, err)
+}